@@ -0,0 +1,92 @@
+package di
+
+type alias struct {
+	name       string
+	deprecated bool
+}
+
+func (ctxb *ContextBuilder) Alias(alias string, name string) {
+	if err := ctxb.AliasOrErr(alias, name); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) AliasOrErr(alias string, name string) *Error {
+	return ctxb.addAlias(alias, name, false)
+}
+
+// DeprecatedAlias registers an alias that reports every use
+// to the hook registered with OnDeprecatedAlias.
+func (ctxb *ContextBuilder) DeprecatedAlias(alias string, name string) {
+	if err := ctxb.DeprecatedAliasOrErr(alias, name); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) DeprecatedAliasOrErr(alias string, name string) *Error {
+	return ctxb.addAlias(alias, name, true)
+}
+
+func (ctxb *ContextBuilder) OnDeprecatedAlias(hook func(alias string, name string)) {
+	ctxb.deprecatedAliasHook = hook
+}
+
+func (ctxb *ContextBuilder) addAlias(name string, target string, deprecated bool) *Error {
+	if _, ok := ctxb.aliases[name]; ok {
+		return ctxb.withCallerLocation(newDuplicatedNameError(name))
+	}
+	ctxb.aliases[name] = alias{name: target, deprecated: deprecated}
+	return nil
+}
+
+// resolveAliases resolves aliases to active names.
+// Aliases of names registered only under inactive profiles are skipped.
+func resolveAliases(aliases map[string]alias, holdersByName map[string]*holder, registered map[string][]*holder) (map[string]alias, *Error) {
+	result := make(map[string]alias, len(aliases))
+	for name, a := range aliases {
+		if holdersByName[name] != nil {
+			return nil, newDuplicatedNameError(name)
+		}
+		path := []string{name}
+		resolved := a
+		inactive := false
+		for holdersByName[resolved.name] == nil {
+			next, ok := aliases[resolved.name]
+			if !ok && len(registered[resolved.name]) > 0 {
+				inactive = true
+				break
+			}
+			if !ok {
+				return nil, newDanglingAliasError(name, resolved.name)
+			}
+			for _, p := range path {
+				if p == resolved.name {
+					return nil, newCyclicAliasError(append(path, resolved.name))
+				}
+			}
+			path = append(path, resolved.name)
+			resolved = alias{name: next.name, deprecated: resolved.deprecated || next.deprecated}
+		}
+		if !inactive {
+			result[name] = resolved
+		}
+	}
+	return result, nil
+}
+
+func (ctx *Context) namedHolder(name string) *holder {
+	if hldr := ctx.holdersByName[name]; hldr != nil {
+		return hldr
+	}
+	if a, ok := ctx.aliases[name]; ok {
+		return ctx.holdersByName[a.name]
+	}
+	return nil
+}
+
+func (ctx *Context) reportAlias(name string) {
+	a, ok := ctx.aliases[name]
+	if ok && a.deprecated && ctx.deprecatedAliasHook != nil {
+		ctx.deprecatedAliasHook(name, a.name)
+	}
+}
@@ -0,0 +1,308 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigSource interface {
+	Lookup(key string) (any, bool)
+}
+
+type Validatable interface {
+	Validate() error
+}
+
+var durationRType = reflect.TypeOf(time.Duration(0))
+
+func (ctxb *ContextBuilder) AddConfigSource(source ConfigSource) {
+	ctxb.configSources = append(ctxb.configSources, source)
+}
+
+func BindConfig[T any](ctxb *ContextBuilder, key string) {
+	if err := BindConfigOrErr[T](ctxb, key); err != nil {
+		panic(err)
+	}
+}
+
+func BindConfigOrErr[T any](ctxb *ContextBuilder, key string) *Error {
+	rtype := genericTypeOf[T]()
+	if rtype.Kind() != reflect.Struct {
+		return newInvalidConfigError(key, fmt.Errorf("expected struct type, got %s", rtype))
+	}
+	hldr := &holder{
+		ctor: func(ctx *Context) (any, error) {
+			obj, err := bindConfig(rtype, key, ctx.configSources)
+			if err != nil {
+				return nil, err
+			}
+			return obj, nil
+		},
+		providesType: rtype,
+	}
+	ctxb.assignHolderId(hldr)
+	if err := ctxb.addHolderForType(hldr, rtype); err != nil {
+		return err
+	}
+	ctxb.configHolders = append(ctxb.configHolders, hldr)
+	return nil
+}
+
+func bindConfig(rtype reflect.Type, key string, sources []ConfigSource) (any, *Error) {
+	value := reflect.New(rtype)
+	if err := bindConfigStruct(value.Elem(), key, sources); err != nil {
+		return nil, err
+	}
+	if validatable, ok := value.Interface().(Validatable); ok {
+		if err := validatable.Validate(); err != nil {
+			return nil, newInvalidConfigError(key, err)
+		}
+	}
+	return value.Elem().Interface(), nil
+}
+
+func bindConfigStruct(value reflect.Value, prefix string, sources []ConfigSource) *Error {
+	rtype := value.Type()
+	for i := 0; i < rtype.NumField(); i++ {
+		field := rtype.Field(i)
+		name := field.Tag.Get("config")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		fvalue := value.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			if err := bindConfigStruct(fvalue, key, sources); err != nil {
+				return err
+			}
+			continue
+		}
+		raw, ok := lookupConfig(key, sources)
+		if !ok {
+			if def, hasDefault := field.Tag.Lookup("default"); hasDefault {
+				raw, ok = def, true
+			}
+		}
+		if !ok {
+			if field.Tag.Get("required") == "true" {
+				return newMissingConfigError(key)
+			}
+			continue
+		}
+		if err := setConfigValue(fvalue, raw); err != nil {
+			return newInvalidConfigError(key, err)
+		}
+	}
+	return nil
+}
+
+func lookupConfig(key string, sources []ConfigSource) (any, bool) {
+	for i := len(sources) - 1; i >= 0; i-- {
+		// null values are treated as unset
+		if value, ok := sources[i].Lookup(key); ok && value != nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func setConfigValue(value reflect.Value, raw any) error {
+	if text, ok := raw.(string); ok {
+		return setConfigText(value, text)
+	}
+	if raw == nil {
+		return fmt.Errorf("unexpected null value")
+	}
+	rvalue := reflect.ValueOf(raw)
+	switch {
+	case rvalue.Kind() == reflect.Slice && value.Kind() == reflect.Slice:
+		result := reflect.MakeSlice(value.Type(), rvalue.Len(), rvalue.Len())
+		for i := 0; i < rvalue.Len(); i++ {
+			if err := setConfigValue(result.Index(i), rvalue.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		value.Set(result)
+		return nil
+	case rvalue.Kind() == reflect.Float64 && isIntKind(value.Kind()) && value.Type() != durationRType:
+		number := rvalue.Float()
+		if number != float64(int64(number)) {
+			return fmt.Errorf("expected integer, got %v", number)
+		}
+		return setConfigText(value, strconv.FormatInt(int64(number), 10))
+	case rvalue.Type().AssignableTo(value.Type()):
+		value.Set(rvalue)
+		return nil
+	default:
+		return setConfigText(value, fmt.Sprint(raw))
+	}
+}
+
+func setConfigText(value reflect.Value, text string) error {
+	if value.Type() == durationRType {
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	case reflect.Slice:
+		parts := strings.Split(text, ",")
+		result := reflect.MakeSlice(value.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setConfigText(result.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		value.Set(result)
+	default:
+		return fmt.Errorf("unsupported config type %s", value.Type())
+	}
+	return nil
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+type mapConfigSource struct {
+	values map[string]any
+}
+
+func ConfigFromMap(values map[string]any) ConfigSource {
+	return &mapConfigSource{values: values}
+}
+
+func ConfigFromJSONFile(path string) (ConfigSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return ConfigFromMap(values), nil
+}
+
+func ConfigFromYAMLFile(path string) (ConfigSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return ConfigFromMap(values), nil
+}
+
+func (s *mapConfigSource) Lookup(key string) (any, bool) {
+	if value, ok := s.values[key]; ok {
+		return value, true
+	}
+	var current any = s.values
+	for _, part := range strings.Split(key, ".") {
+		values, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = values[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+type envConfigSource struct {
+	prefix string
+}
+
+func ConfigFromEnv(prefix string) ConfigSource {
+	return &envConfigSource{prefix: prefix}
+}
+
+func (s *envConfigSource) Lookup(key string) (any, bool) {
+	name := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+	if s.prefix != "" {
+		name = strings.ToUpper(s.prefix) + "_" + name
+	}
+	return os.LookupEnv(name)
+}
+
+type flagConfigSource struct {
+	values map[string]string
+}
+
+func ConfigFromFlags(args []string) ConfigSource {
+	values := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		arg = strings.TrimLeft(arg, "-")
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			values[name] = value
+		} else if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			values[arg] = args[i+1]
+			i++
+		} else {
+			values[arg] = "true"
+		}
+	}
+	return &flagConfigSource{values: values}
+}
+
+func (s *flagConfigSource) Lookup(key string) (any, bool) {
+	value, ok := s.values[key]
+	return value, ok
+}
@@ -8,11 +8,22 @@ import (
 )
 
 type Context struct {
-	path          map[string]int
-	holdersByType map[reflect.Type][]*holder
-	holdersByName map[string]*holder
-	initialized   bool
-	shutdown      bool
+	path                map[string]int
+	holdersByType       map[reflect.Type][]*holder
+	holdersByName       map[string]*holder
+	holdersByKey        map[keyId]*holder
+	instances           map[*holder]*instance
+	aliases             map[string]alias
+	deprecatedAliasHook func(alias string, name string)
+	holdersByGroup      map[string][]*holder
+	activeProfiles      []string
+	configSources       []ConfigSource
+	implicitIfaces      bool
+	lazyInit            bool
+	repanic             bool
+	invocations         []*invocation
+	initialized         bool
+	shutdown            bool
 }
 
 func (ctx *Context) Initialize() {
@@ -29,30 +40,19 @@ func (ctx *Context) InitializeOrErr() *Error {
 	if ctx.shutdown {
 		return newLifecycleError("context already shutdown")
 	}
-	deps := ctx.GetAllByType(new(Initializable))
-	for _, dep := range deps {
-		initializable := dep.(Initializable)
-		err := func() (suberr error) {
-			defer func() {
-				if r := recover(); r != nil {
-					switch x := r.(type) {
-					case string:
-						suberr = errors.New(x)
-					case error:
-						suberr = x
-					default:
-						suberr = errors.New("shutdown panic")
-					}
-				}
-			}()
-			initializable.Initialize()
-			return nil
-		}()
-		if err != nil {
-			depType := reflect.TypeOf(dep)
-			return newInitializationError(&depType, err)
+	for _, inv := range ctx.invocations {
+		if err := inv.invoke(ctx); err != nil {
+			return err
+		}
+	}
+	if !ctx.lazyInit {
+		if err := ctx.createInstances(ctx.holdersByType[initializableRType]); err != nil {
+			return err
 		}
 	}
+	if err := ctx.initializeInstances(); err != nil {
+		return err
+	}
 	ctx.initialized = true
 	return nil
 }
@@ -68,31 +68,8 @@ func (ctx *Context) ShutdownOrErr(context stdcontext.Context) *Error {
 	if ctx.shutdown {
 		return newLifecycleError("context already shutdown")
 	}
-	rtype := reflect.TypeOf(new(Shutdownable)).Elem()
-	holders := ctx.holdersByType[rtype]
-	for _, holder := range holders {
-		if holder.created {
-			shutdownable := holder.instance.(Shutdownable)
-			err := func() (suberr error) {
-				defer func() {
-					if r := recover(); r != nil {
-						switch x := r.(type) {
-						case string:
-							suberr = errors.New(x)
-						case error:
-							suberr = x
-						default:
-							suberr = errors.New("shutdown panic")
-						}
-					}
-				}()
-				shutdownable.Shutdown(context)
-				return nil
-			}()
-			if err != nil {
-				return newShutdownError(&holder.providesType, err)
-			}
-		}
+	if err := ctx.shutdownInstances(context); err != nil {
+		return err
 	}
 	ctx.shutdown = true
 	return nil
@@ -110,10 +87,13 @@ func (ctx *Context) GetNamedOrErr(name string) (any, *Error) {
 	if ctx.shutdown {
 		return nil, newLifecycleError("context already shutdown")
 	}
-	holder := ctx.holdersByName[name]
+	holder := ctx.namedHolder(name)
 	if holder == nil {
-		return empty[any](), newMissingDependencyError(&name, nil)
+		return empty[any](), newMissingDependencyError(&name, nil).
+			withPath(ctx.resolutionPath(descriptor(&name, nil))).
+			withSuggestions(ctx.nameSuggestions(name))
 	}
+	ctx.reportAlias(name)
 	depCtx, err := dependencyContext(ctx, descriptor(&name, nil))
 	if err != nil {
 		return empty[any](), err
@@ -121,13 +101,13 @@ func (ctx *Context) GetNamedOrErr(name string) (any, *Error) {
 	obj, cerr := holder.getOrCreate(depCtx)
 	if cerr != nil {
 		if !errors.Is(cerr, ErrSkippedDependency) {
-			creationErr := newDependencyCreationError(&name, nil, cerr)
+			creationErr := newDependencyCreationError(&name, nil, holder, depCtx.resolutionPath(""), cerr)
 			return empty[any](), creationErr
 		}
 	} else {
 		return obj, nil
 	}
-	return empty[any](), newMissingDependencyError(&name, nil)
+	return empty[any](), newMissingDependencyError(&name, nil).withPath(ctx.resolutionPath(descriptor(&name, nil)))
 }
 
 func (ctx *Context) GetByType(atype any) any {
@@ -161,8 +141,20 @@ func (ctx *Context) getByRType(rtype reflect.Type) (any, *Error) {
 		return nil, newLifecycleError("context already shutdown")
 	}
 	holders := ctx.holdersByType[rtype]
+	if holders == nil && ctx.implicitIfaces && rtype.Kind() == reflect.Interface {
+		holders = ctx.implementingHolders(rtype)
+		if len(holders) > 1 {
+			candidates := make([]string, len(holders))
+			for i, holder := range holders {
+				candidates[i] = ctx.holderDescriptor(holder)
+			}
+			return empty[any](), newAmbiguousDependencyError(rtype, candidates).withPath(ctx.resolutionPath(descriptor(nil, &rtype)))
+		}
+	}
 	if holders == nil {
-		return empty[any](), newMissingDependencyError(nil, &rtype)
+		return empty[any](), newMissingDependencyError(nil, &rtype).
+			withPath(ctx.resolutionPath(descriptor(nil, &rtype))).
+			withSuggestions(ctx.typeSuggestions(rtype))
 	}
 	for _, holder := range holders {
 		depCtx, err := dependencyContext(ctx, descriptor(nil, &rtype))
@@ -172,21 +164,63 @@ func (ctx *Context) getByRType(rtype reflect.Type) (any, *Error) {
 		obj, cerr := holder.getOrCreate(depCtx)
 		if cerr != nil {
 			if !errors.Is(cerr, ErrSkippedDependency) {
-				creationErr := newDependencyCreationError(nil, &rtype, cerr)
+				creationErr := newDependencyCreationError(nil, &rtype, holder, depCtx.resolutionPath(""), cerr)
 				return empty[any](), creationErr
 			}
 		} else {
 			return obj, nil
 		}
 	}
-	return empty[any](), newMissingDependencyError(nil, &rtype)
+	return empty[any](), newMissingDependencyError(nil, &rtype).withPath(ctx.resolutionPath(descriptor(nil, &rtype)))
 }
 
 func (ctx *Context) getAllByRType(rtype reflect.Type) ([]any, *Error) {
 	if ctx.shutdown {
 		return nil, newLifecycleError("context already shutdown")
 	}
-	holders := ctx.holdersByType[rtype]
+	return ctx.getAllFromHolders(ctx.holdersByType[rtype], rtype)
+}
+
+func (ctx *Context) getAllNamedByRType(rtype reflect.Type) (map[string]any, *Error) {
+	if ctx.shutdown {
+		return nil, newLifecycleError("context already shutdown")
+	}
+	result := make(map[string]any)
+	for name, holder := range ctx.holdersByName {
+		if !holder.providesType.AssignableTo(rtype) {
+			continue
+		}
+		depCtx, err := dependencyContext(ctx, descriptor(&name, &rtype))
+		if err != nil {
+			return nil, err
+		}
+		obj, cerr := holder.getOrCreate(depCtx)
+		if cerr != nil {
+			if !errors.Is(cerr, ErrSkippedDependency) {
+				creationErr := newDependencyCreationError(&name, &rtype, holder, depCtx.resolutionPath(""), cerr)
+				return nil, creationErr
+			}
+		} else {
+			result[name] = obj
+		}
+	}
+	return result, nil
+}
+
+func (ctx *Context) getGroupByRType(group string, rtype reflect.Type) ([]any, *Error) {
+	if ctx.shutdown {
+		return nil, newLifecycleError("context already shutdown")
+	}
+	holders := make([]*holder, 0)
+	for _, holder := range ctx.holdersByGroup[group] {
+		if holder.providesType.AssignableTo(rtype) {
+			holders = append(holders, holder)
+		}
+	}
+	return ctx.getAllFromHolders(holders, rtype)
+}
+
+func (ctx *Context) getAllFromHolders(holders []*holder, rtype reflect.Type) ([]any, *Error) {
 	result := make([]any, 0)
 	for _, holder := range holders {
 		depCtx, err := dependencyContext(ctx, descriptor(nil, &rtype))
@@ -196,7 +230,7 @@ func (ctx *Context) getAllByRType(rtype reflect.Type) ([]any, *Error) {
 		obj, cerr := holder.getOrCreate(depCtx)
 		if cerr != nil {
 			if !errors.Is(cerr, ErrSkippedDependency) {
-				creationErr := newDependencyCreationError(nil, &rtype, cerr)
+				creationErr := newDependencyCreationError(nil, &rtype, holder, depCtx.resolutionPath(""), cerr)
 				return nil, creationErr
 			}
 		} else {
@@ -206,13 +240,30 @@ func (ctx *Context) getAllByRType(rtype reflect.Type) ([]any, *Error) {
 	return result, nil
 }
 
+func (ctx *Context) implementingHolders(rtype reflect.Type) []*holder {
+	var result []*holder
+	for _, holder := range ctx.holders() {
+		if holder.providesType.Implements(rtype) {
+			result = append(result, holder)
+		}
+	}
+	return result
+}
+
+func (ctx *Context) resolutionPath(descriptor string) []string {
+	result := make([]string, len(ctx.path))
+	for d, i := range ctx.path {
+		result[i-1] = d
+	}
+	if descriptor != "" {
+		result = append(result, descriptor)
+	}
+	return result
+}
+
 func dependencyContext(ctx *Context, descriptor string) (*Context, *Error) {
 	if ctx.path[descriptor] > 0 {
-		cycle := make([]string, len(ctx.path))
-		for d, i := range ctx.path {
-			cycle[i-1] = d
-		}
-		return nil, newCyclicDependencyError(cycle)
+		return nil, newCyclicDependencyError(ctx.resolutionPath(""))
 	}
 	path := make(map[string]int)
 	for k, v := range ctx.path {
@@ -220,13 +271,58 @@ func dependencyContext(ctx *Context, descriptor string) (*Context, *Error) {
 	}
 	path[descriptor] = len(path) + 1
 	sub := Context{
-		path:          path,
-		holdersByType: ctx.holdersByType,
-		holdersByName: ctx.holdersByName,
+		path:                path,
+		holdersByType:       ctx.holdersByType,
+		holdersByName:       ctx.holdersByName,
+		holdersByKey:        ctx.holdersByKey,
+		instances:           ctx.instances,
+		aliases:             ctx.aliases,
+		deprecatedAliasHook: ctx.deprecatedAliasHook,
+		holdersByGroup:      ctx.holdersByGroup,
+		activeProfiles:      ctx.activeProfiles,
+		configSources:       ctx.configSources,
+		implicitIfaces:      ctx.implicitIfaces,
+		lazyInit:            ctx.lazyInit,
+		initialized:         ctx.initialized,
+		shutdown:            ctx.shutdown,
+		repanic:             ctx.repanic,
 	}
 	return &sub, nil
 }
 
+func (ctx *Context) createEagerInstances() *Error {
+	eager := make([]*holder, 0)
+	for _, hldr := range ctx.holders() {
+		if hldr.eager {
+			eager = append(eager, hldr)
+		}
+	}
+	return ctx.createInstances(eager)
+}
+
+// createInstances creates instances of the holders without marking them as used.
+func (ctx *Context) createInstances(holders []*holder) *Error {
+	for _, hldr := range holders {
+		depCtx, err := dependencyContext(ctx, ctx.holderDescriptor(hldr))
+		if err != nil {
+			return err
+		}
+		if _, cerr := hldr.create(depCtx); cerr != nil && !errors.Is(cerr, ErrSkippedDependency) {
+			return newDependencyCreationError(nil, &hldr.providesType, hldr, depCtx.resolutionPath(""), cerr)
+		}
+	}
+	return nil
+}
+
+func (ctx *Context) instanceOf(hldr *holder) *instance {
+	inst := ctx.instances[hldr]
+	if inst == nil {
+		inst = &instance{value: hldr.instance, created: hldr.created}
+		ctx.instances[hldr] = inst
+	}
+	return inst
+}
+
 func descriptor(objName *string, objType *reflect.Type) string {
 	var result string
 	if objName != nil && objType != nil {
@@ -8,180 +8,424 @@ import (
 )
 
 type ContextBuilder struct {
-	holdersByCtors map[any]*holder
-	holdersByType  map[reflect.Type]*coll.Set[*holder]
-	holdersByName  map[string]*holder
+	holdersByCtors      map[any][]*holder
+	holdersByType       map[reflect.Type]*coll.Set[*holder]
+	holdersByName       map[string][]*holder
+	holdersByKey        map[keyId][]*holder
+	aliases             map[string]alias
+	deprecatedAliasHook func(alias string, name string)
+	moduleOptions       []Option
+	profiles            []string
+	exposedInterfaces   []reflect.Type
+	implicitIfaces      bool
+	lazyInit            bool
+	repanic             bool
+	noLocations         bool
+	roots               []Dependency
+	configSources       []ConfigSource
+	configHolders       []*holder
+	invocations         []*invocation
+	lastHolderId        int
 }
 
 func NewContextBuilder() *ContextBuilder {
 	return &ContextBuilder{
-		holdersByCtors: make(map[any]*holder),
+		holdersByCtors: make(map[any][]*holder),
 		holdersByType:  make(map[reflect.Type]*coll.Set[*holder]),
-		holdersByName:  make(map[string]*holder),
+		holdersByName:  make(map[string][]*holder),
+		holdersByKey:   make(map[keyId][]*holder),
+		aliases:        make(map[string]alias),
 	}
 }
 
 func (ctxb *ContextBuilder) Build() *Context {
+	ctx, err := ctxb.BuildOrErr()
+	if err != nil {
+		panic(err)
+	}
+	return ctx
+}
+
+func (ctxb *ContextBuilder) BuildOrErr() (*Context, *Error) {
+	profiles := activeProfiles(ctxb.profiles)
 	holders := make(map[reflect.Type][]*holder)
 	for k, v := range ctxb.holdersByType {
-		holders[k] = v.ToSlice()
+		active := filterActiveHolders(v.ToSlice(), profiles)
+		if len(active) > 0 {
+			holders[k] = active
+		}
+	}
+	holdersByName := make(map[string]*holder)
+	for name, v := range ctxb.holdersByName {
+		active := filterActiveHolders(v, profiles)
+		if len(active) > 1 {
+			return nil, newDuplicatedNameError(name)
+		}
+		if len(active) == 1 {
+			holdersByName[name] = active[0]
+		}
+	}
+	aliases, err := resolveAliases(ctxb.aliases, holdersByName, ctxb.holdersByName)
+	if err != nil {
+		return nil, err
+	}
+	holdersByKey := make(map[keyId]*holder)
+	for key, v := range ctxb.holdersByKey {
+		active := filterActiveHolders(v, profiles)
+		if len(active) > 1 {
+			return nil, newDuplicatedKeyError(key)
+		}
+		if len(active) == 1 {
+			holdersByKey[key] = active[0]
+		}
+	}
+	ctx := &Context{
+		holdersByType:       holders,
+		holdersByName:       holdersByName,
+		holdersByKey:        holdersByKey,
+		instances:           make(map[*holder]*instance),
+		holdersByGroup:      make(map[string][]*holder),
+		aliases:             aliases,
+		deprecatedAliasHook: ctxb.deprecatedAliasHook,
+		activeProfiles:      profiles,
+		configSources:       ctxb.configSources,
+		implicitIfaces:      ctxb.implicitIfaces,
+		lazyInit:            ctxb.lazyInit,
+		repanic:             ctxb.repanic,
+	}
+	for _, hldr := range ctx.holders() {
+		for _, group := range hldr.groups {
+			ctx.holdersByGroup[group] = append(ctx.holdersByGroup[group], hldr)
+		}
+	}
+	for _, hldr := range ctxb.configHolders {
+		obj, err := hldr.ctor(ctx)
+		if err != nil {
+			return nil, err.(*Error)
+		}
+		inst := ctx.instanceOf(hldr)
+		inst.value = obj
+		inst.created = true
 	}
-	return &Context{
-		holdersByType: holders,
-		holdersByName: ctxb.holdersByName,
+	if len(ctxb.roots) > 0 {
+		roots := ctxb.roots
+		for _, inv := range ctxb.invocations {
+			roots = append(roots[:len(roots):len(roots)], inv.deps...)
+		}
+		if err := validateReachability(ctx, roots); err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.createEagerInstances(); err != nil {
+		return nil, err
+	}
+	for _, inv := range ctxb.invocations {
+		if inv.onInit {
+			ctx.invocations = append(ctx.invocations, inv)
+		} else if err := inv.invoke(ctx); err != nil {
+			return nil, err
+		}
 	}
+	return ctx, nil
 }
 
-func (ctxb *ContextBuilder) Add(ctor any) {
-	if err := ctxb.AddOrErr(ctor); err != nil {
+func (ctxb *ContextBuilder) AddModule(module Module, opts ...Option) {
+	parent := ctxb.moduleOptions
+	ctxb.moduleOptions = append(parent[:len(parent):len(parent)], opts...)
+	defer func() {
+		ctxb.moduleOptions = parent
+	}()
+	module(ctxb)
+}
+
+func (ctxb *ContextBuilder) ExposeInterfaces(ifaces ...any) {
+	if err := ctxb.ExposeInterfacesOrErr(ifaces...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) AddOrErr(ctor any) *Error {
-	return ctxb.addOrErr(ctor, false)
+func (ctxb *ContextBuilder) ExposeInterfacesOrErr(ifaces ...any) *Error {
+	rtypes := elemTypes(ifaces)
+	for _, rtype := range rtypes {
+		if rtype.Kind() != reflect.Interface {
+			return newExpectedInterfaceError(rtype)
+		}
+	}
+	return ctxb.exposeInterfaces(rtypes)
+}
+
+// exposeInterfaces exposes already registered dependencies,
+// subsequent registrations are exposed when added.
+func (ctxb *ContextBuilder) exposeInterfaces(rtypes []reflect.Type) *Error {
+	for _, rtype := range rtypes {
+		if !containsType(ctxb.exposedInterfaces, rtype) {
+			ctxb.exposedInterfaces = append(ctxb.exposedInterfaces, rtype)
+		}
+	}
+	for _, hldr := range ctxb.holders() {
+		if err := ctxb.addHolderForInterfaces(hldr, ctxb.exposedInterfaces); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (ctxb *ContextBuilder) Provide(ctor any) {
-	if err := ctxb.ProvideOrErr(ctor); err != nil {
+func (ctxb *ContextBuilder) EnableImplicitInterfaces() {
+	ctxb.implicitIfaces = true
+}
+
+// EnableLazyInitialization makes Initialize skip dependencies that were not created yet.
+// Dependencies created after Initialize are initialized right after creation.
+func (ctxb *ContextBuilder) EnableLazyInitialization() {
+	ctxb.lazyInit = true
+}
+
+// DisablePanicRecovery makes panics from constructors, lifecycle hooks and
+// invocations propagate instead of being returned as errors.
+// Panics with ErrSkippedDependency are always recovered.
+func (ctxb *ContextBuilder) DisablePanicRecovery() {
+	ctxb.repanic = true
+}
+
+// DisableSourceLocations stops capturing file and line of registration calls
+// for introspection and registration errors.
+func (ctxb *ContextBuilder) DisableSourceLocations() {
+	ctxb.noLocations = true
+}
+
+func (ctxb *ContextBuilder) ActivateProfiles(profiles ...string) {
+	ctxb.profiles = append(ctxb.profiles, profiles...)
+}
+
+func (ctxb *ContextBuilder) DeclareRoots(atypes ...any) {
+	for _, rtype := range elemTypes(atypes) {
+		ctxb.roots = append(ctxb.roots, Dependency{Type: rtype})
+	}
+}
+
+func (ctxb *ContextBuilder) Add(ctor any, opts ...Option) {
+	if err := ctxb.AddOrErr(ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) AddOrErr(ctor any, opts ...Option) *Error {
+	return ctxb.addOrErr(ctor, false, opts)
+}
+
+func (ctxb *ContextBuilder) Provide(ctor any, opts ...Option) {
+	if err := ctxb.ProvideOrErr(ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) ProvideOrErr(ctor any) *Error {
-	return ctxb.addOrErr(ctor, true)
+func (ctxb *ContextBuilder) ProvideOrErr(ctor any, opts ...Option) *Error {
+	return ctxb.addOrErr(ctor, true, opts)
 }
 
-func (ctxb *ContextBuilder) addOrErr(ctor any, lazy bool) *Error {
-	hldr, err := createUniqueHolder(ctxb, ctor, lazy)
+func (ctxb *ContextBuilder) ProvideEager(ctor any, opts ...Option) {
+	if err := ctxb.ProvideEagerOrErr(ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) ProvideEagerOrErr(ctor any, opts ...Option) *Error {
+	return ctxb.addOrErr(ctor, true, append(opts[:len(opts):len(opts)], Eager()))
+}
+
+func (ctxb *ContextBuilder) addOrErr(ctor any, lazy bool, opts []Option) *Error {
+	return ctxb.addWithInterfacesOrErr(ctor, nil, lazy, opts)
+}
+
+// AddWithInterfaces registers a dependency under its own type and the passed interfaces.
+// Options, like Profile or Group, can be passed along with the interfaces.
+func (ctxb *ContextBuilder) AddWithInterfaces(ctor any, ifacesAndOpts ...any) {
+	if err := ctxb.AddWithInterfacesOrErr(ctor, ifacesAndOpts...); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) AddWithInterfacesOrErr(ctor any, ifacesAndOpts ...any) *Error {
+	ifaces, opts := splitOptions(ifacesAndOpts)
+	return ctxb.addWithInterfacesOrErr(ctor, elemTypes(ifaces), false, opts)
+}
+
+// ProvideWithInterfaces registers a constructor under its result type and the passed interfaces.
+// Options, like Profile or Group, can be passed along with the interfaces.
+func (ctxb *ContextBuilder) ProvideWithInterfaces(ctor any, ifacesAndOpts ...any) {
+	if err := ctxb.ProvideWithInterfacesOrErr(ctor, ifacesAndOpts...); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) ProvideWithInterfacesOrErr(ctor any, ifacesAndOpts ...any) *Error {
+	ifaces, opts := splitOptions(ifacesAndOpts)
+	return ctxb.addWithInterfacesOrErr(ctor, elemTypes(ifaces), true, opts)
+}
+
+func (ctxb *ContextBuilder) addWithInterfacesOrErr(ctor any, ifaces []reflect.Type, lazy bool, opts []Option) (err *Error) {
+	defer func() { err = ctxb.withCallerLocation(err) }()
+	if len(ifaces) > 0 {
+		hldr, err := createUniqueHolder(ctxb, ctor, lazy, opts)
+		if err != nil {
+			return err
+		}
+		return ctxb.addHolder(hldr, ifaces)
+	}
+	hldrs, err := createUniqueHolders(ctxb, ctor, lazy, opts)
 	if err != nil {
 		return err
 	}
-	if hldr.providesType.Implements(initializableRType) {
-		err = ctxb.addHolderForType(hldr, initializableRType)
-		if err != nil {
+	for _, hldr := range hldrs {
+		if err := ctxb.addHolder(hldr, nil); err != nil {
 			return err
 		}
 	}
-	if hldr.providesType.Implements(shutdownableRType) {
-		err = ctxb.addHolderForType(hldr, shutdownableRType)
-		if err != nil {
+	return nil
+}
+
+func (ctxb *ContextBuilder) addHolder(hldr *holder, ifaces []reflect.Type) *Error {
+	for _, iface := range ifaces {
+		if iface.Kind() != reflect.Interface {
+			return newExpectedInterfaceError(iface)
+		}
+		if !hldr.providesType.Implements(iface) {
+			return newInvalidTypeError(nil, hldr.providesType, iface)
+		}
+	}
+	if hldr.outName != "" {
+		if err := ctxb.addHolderForName(hldr, hldr.outName); err != nil {
 			return err
 		}
 	}
-	err = ctxb.addHolderForType(hldr, hldr.providesType)
-	if err != nil {
+	if err := ctxb.addHolderForType(hldr, hldr.providesType); err != nil {
 		return err
 	}
-	return nil
+	return ctxb.addHolderForInterfaces(hldr, append(append(ifaces, ctxb.exposedInterfaces...), lifecycleRTypes...))
 }
 
-func (ctxb *ContextBuilder) AddNamed(name string, ctor any) {
-	if err := ctxb.AddNamedOrErr(name, ctor); err != nil {
+func (ctxb *ContextBuilder) AddNamed(name string, ctor any, opts ...Option) {
+	if err := ctxb.AddNamedOrErr(name, ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) AddNamedOrErr(name string, ctor any) *Error {
-	return ctxb.addNamedOrErr(name, ctor, false)
+func (ctxb *ContextBuilder) AddNamedOrErr(name string, ctor any, opts ...Option) *Error {
+	return ctxb.addNamedOrErr(name, ctor, false, opts)
 }
 
-func (ctxb *ContextBuilder) ProvideNamed(name string, ctor any) {
-	if err := ctxb.ProvideNamedOrErr(name, ctor); err != nil {
+func (ctxb *ContextBuilder) ProvideNamed(name string, ctor any, opts ...Option) {
+	if err := ctxb.ProvideNamedOrErr(name, ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) ProvideNamedOrErr(name string, ctor any) *Error {
-	return ctxb.addNamedOrErr(name, ctor, true)
+func (ctxb *ContextBuilder) ProvideNamedOrErr(name string, ctor any, opts ...Option) *Error {
+	return ctxb.addNamedOrErr(name, ctor, true, opts)
 }
 
-func (ctxb *ContextBuilder) addNamedOrErr(name string, ctor any, lazy bool) *Error {
-	hldr, err := createUniqueHolder(ctxb, ctor, lazy)
+func (ctxb *ContextBuilder) addNamedOrErr(name string, ctor any, lazy bool, opts []Option) (err *Error) {
+	defer func() { err = ctxb.withCallerLocation(err) }()
+	hldr, err := createUniqueHolder(ctxb, ctor, lazy, opts)
 	if err != nil {
 		return err
 	}
+	named := ctxb.hasHolderForName(hldr, name)
 	err = ctxb.addHolderForName(hldr, name)
 	if err != nil {
 		return err
 	}
 	err = ctxb.addHolderForType(hldr, hldr.providesType)
 	if err != nil {
-		delete(ctxb.holdersByName, name)
+		if !named {
+			ctxb.removeHolderForName(hldr, name)
+		}
 		return err
 	}
-	return nil
+	if err := ctxb.addHolderForInterfaces(hldr, ctxb.exposedInterfaces); err != nil {
+		return err
+	}
+	return ctxb.addHolderForInterfaces(hldr, lifecycleRTypes)
 }
 
-func (ctxb *ContextBuilder) AddAs(atype any, ctor any) {
-	if err := ctxb.AddAsOrErr(atype, ctor); err != nil {
+func (ctxb *ContextBuilder) AddAs(atype any, ctor any, opts ...Option) {
+	if err := ctxb.AddAsOrErr(atype, ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) AddAsOrErr(atype any, ctor any) *Error {
-	return ctxb.addAsOrErr(atype, ctor, false)
+func (ctxb *ContextBuilder) AddAsOrErr(atype any, ctor any, opts ...Option) *Error {
+	return ctxb.addAsOrErr(reflect.TypeOf(atype).Elem(), ctor, false, opts)
 }
 
-func (ctxb *ContextBuilder) ProvideAs(atype any, ctor any) {
-	if err := ctxb.ProvideAsOrErr(atype, ctor); err != nil {
+func (ctxb *ContextBuilder) ProvideAs(atype any, ctor any, opts ...Option) {
+	if err := ctxb.ProvideAsOrErr(atype, ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) ProvideAsOrErr(atype any, ctor any) *Error {
-	return ctxb.addAsOrErr(atype, ctor, true)
+func (ctxb *ContextBuilder) ProvideAsOrErr(atype any, ctor any, opts ...Option) *Error {
+	return ctxb.addAsOrErr(reflect.TypeOf(atype).Elem(), ctor, true, opts)
 }
 
-func (ctxb *ContextBuilder) addAsOrErr(atype any, ctor any, lazy bool) *Error {
-	hldr, err := createUniqueHolder(ctxb, ctor, lazy)
+func (ctxb *ContextBuilder) addAsOrErr(rtype reflect.Type, ctor any, lazy bool, opts []Option) (err *Error) {
+	defer func() { err = ctxb.withCallerLocation(err) }()
+	hldr, err := createUniqueHolder(ctxb, ctor, lazy, opts)
 	if err != nil {
 		return err
 	}
-	rtype := reflect.TypeOf(atype).Elem()
 	err = ctxb.addHolderForType(hldr, rtype)
 	if err != nil {
 		return err
 	}
-	return nil
+	if err := ctxb.addHolderForInterfaces(hldr, ctxb.exposedInterfaces); err != nil {
+		return err
+	}
+	return ctxb.addHolderForInterfaces(hldr, lifecycleRTypes)
 }
 
-func (ctxb *ContextBuilder) AddNamedAs(name string, atype any, ctor any) {
-	if err := ctxb.AddNamedAsOrErr(name, atype, ctor); err != nil {
+func (ctxb *ContextBuilder) AddNamedAs(name string, atype any, ctor any, opts ...Option) {
+	if err := ctxb.AddNamedAsOrErr(name, atype, ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) AddNamedAsOrErr(name string, atype any, ctor any) *Error {
-	return ctxb.addNamedAsOrErr(name, atype, ctor, false)
+func (ctxb *ContextBuilder) AddNamedAsOrErr(name string, atype any, ctor any, opts ...Option) *Error {
+	return ctxb.addNamedAsOrErr(name, reflect.TypeOf(atype).Elem(), ctor, false, opts)
 }
 
-func (ctxb *ContextBuilder) ProvideNamedAs(name string, atype any, ctor any) {
-	if err := ctxb.ProvideNamedAsOrErr(name, atype, ctor); err != nil {
+func (ctxb *ContextBuilder) ProvideNamedAs(name string, atype any, ctor any, opts ...Option) {
+	if err := ctxb.ProvideNamedAsOrErr(name, atype, ctor, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func (ctxb *ContextBuilder) ProvideNamedAsOrErr(name string, atype any, ctor any) *Error {
-	return ctxb.addNamedAsOrErr(name, atype, ctor, true)
+func (ctxb *ContextBuilder) ProvideNamedAsOrErr(name string, atype any, ctor any, opts ...Option) *Error {
+	return ctxb.addNamedAsOrErr(name, reflect.TypeOf(atype).Elem(), ctor, true, opts)
 }
 
-func (ctxb *ContextBuilder) addNamedAsOrErr(name string, atype any, ctor any, lazy bool) *Error {
-	hldr, err := createUniqueHolder(ctxb, ctor, lazy)
+func (ctxb *ContextBuilder) addNamedAsOrErr(name string, rtype reflect.Type, ctor any, lazy bool, opts []Option) (err *Error) {
+	defer func() { err = ctxb.withCallerLocation(err) }()
+	hldr, err := createUniqueHolder(ctxb, ctor, lazy, opts)
 	if err != nil {
 		return err
 	}
+	named := ctxb.hasHolderForName(hldr, name)
 	err = ctxb.addHolderForName(hldr, name)
 	if err != nil {
 		return err
 	}
-	rtype := reflect.TypeOf(atype).Elem()
 	err = ctxb.addHolderForType(hldr, rtype)
 	if err != nil {
-		delete(ctxb.holdersByName, name)
+		if !named {
+			ctxb.removeHolderForName(hldr, name)
+		}
 		return err
 	}
-	return nil
+	if err := ctxb.addHolderForInterfaces(hldr, ctxb.exposedInterfaces); err != nil {
+		return err
+	}
+	return ctxb.addHolderForInterfaces(hldr, lifecycleRTypes)
 }
 
 func (ctxb *ContextBuilder) addHolderForType(hldr *holder, rtype reflect.Type) *Error {
@@ -198,15 +442,108 @@ func (ctxb *ContextBuilder) addHolderForType(hldr *holder, rtype reflect.Type) *
 	return nil
 }
 
+func (ctxb *ContextBuilder) addHolderForInterfaces(hldr *holder, ifaces []reflect.Type) *Error {
+	for _, iface := range ifaces {
+		if !hldr.providesType.Implements(iface) {
+			continue
+		}
+		if ctxb.holdersByType[iface] != nil && ctxb.holdersByType[iface].Contains(hldr) {
+			continue
+		}
+		if err := ctxb.addHolderForType(hldr, iface); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ctxb *ContextBuilder) addHolderForName(hldr *holder, name string) *Error {
-	if ctxb.holdersByName[name] != nil && ctxb.holdersByName[name] != hldr {
-		return newDuplicatedNameError(name)
+	for _, h := range ctxb.holdersByName[name] {
+		if h == hldr {
+			return nil
+		}
+		if isConflictingHolder(h, hldr) {
+			return newDuplicatedNameError(name)
+		}
 	}
-	ctxb.holdersByName[name] = hldr
+	ctxb.holdersByName[name] = append(ctxb.holdersByName[name], hldr)
 	return nil
 }
 
-func createUniqueHolder(ctxb *ContextBuilder, ctor any, lazy bool) (*holder, *Error) {
+func (ctxb *ContextBuilder) hasHolderForName(hldr *holder, name string) bool {
+	for _, h := range ctxb.holdersByName[name] {
+		if h == hldr {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctxb *ContextBuilder) removeHolderForName(hldr *holder, name string) {
+	holders := ctxb.holdersByName[name]
+	for i, h := range holders {
+		if h == hldr {
+			ctxb.holdersByName[name] = append(holders[:i:i], holders[i+1:]...)
+			break
+		}
+	}
+	if len(ctxb.holdersByName[name]) == 0 {
+		delete(ctxb.holdersByName, name)
+	}
+}
+
+func createUniqueHolder(ctxb *ContextBuilder, ctor any, lazy bool, opts []Option) (*holder, *Error) {
+	hldrs, err := createUniqueHolders(ctxb, ctor, lazy, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(hldrs) != 1 {
+		return nil, newInvalidConstructorError("expected one result value with an optional error")
+	}
+	return hldrs[0], nil
+}
+
+func createUniqueHolders(ctxb *ContextBuilder, ctor any, lazy bool, opts []Option) ([]*holder, *Error) {
+	hldrs, created, err := findOrCreateHolders(ctxb, ctor, lazy)
+	if err != nil {
+		return nil, err
+	}
+	for _, hldr := range hldrs {
+		if created {
+			applyOptions(hldr, ctxb.moduleOptions, opts)
+			continue
+		}
+		if len(ctxb.moduleOptions) == 0 && len(opts) == 0 {
+			continue
+		}
+		// options of an already registered holder are never changed
+		probe := &holder{}
+		applyOptions(probe, ctxb.moduleOptions, opts)
+		if !hasSameOptions(hldr, probe) {
+			return nil, newConflictingOptionsError()
+		}
+	}
+	return hldrs, nil
+}
+
+func applyOptions(hldr *holder, opts ...[]Option) {
+	for _, o := range opts {
+		for _, opt := range o {
+			opt(hldr)
+		}
+	}
+}
+
+func hasSameOptions(hldr *holder, other *holder) bool {
+	samePhase := hldr.phase == other.phase ||
+		(hldr.phase != nil && other.phase != nil && *hldr.phase == *other.phase)
+	return samePhase &&
+		hldr.eager == other.eager &&
+		reflect.DeepEqual(hldr.profiles, other.profiles) &&
+		reflect.DeepEqual(hldr.groups, other.groups)
+}
+
+func findOrCreateHolders(ctxb *ContextBuilder, ctor any, lazy bool) ([]*holder, bool, *Error) {
 	cval := reflect.ValueOf(ctor)
 	ckind := cval.Kind()
 	var ptr string
@@ -215,16 +552,35 @@ func createUniqueHolder(ctxb *ContextBuilder, ctor any, lazy bool) (*holder, *Er
 	} else if ckind == reflect.Func || ckind == reflect.Pointer {
 		ptr = fmt.Sprintf("ptr-%v-%p", lazy, ctor)
 	} else {
-		return newHolder(ctor, lazy)
+		hldrs, err := createHolders(ctxb, ctor, lazy)
+		return hldrs, true, err
 	}
-	hldr := ctxb.holdersByCtors[ptr]
-	if hldr == nil {
-		nhldr, err := newHolder(ctor, lazy)
-		if err != nil {
-			return nil, err
-		}
-		ctxb.holdersByCtors[ptr] = nhldr
-		hldr = nhldr
+	if hldrs := ctxb.holdersByCtors[ptr]; hldrs != nil {
+		return hldrs, false, nil
+	}
+	hldrs, err := createHolders(ctxb, ctor, lazy)
+	if err != nil {
+		return nil, false, err
+	}
+	ctxb.holdersByCtors[ptr] = hldrs
+	return hldrs, true, nil
+}
+
+func createHolders(ctxb *ContextBuilder, ctor any, lazy bool) ([]*holder, *Error) {
+	hldrs, err := newHolders(ctor, lazy)
+	if err != nil {
+		return nil, err
+	}
+	for _, hldr := range hldrs {
+		ctxb.assignHolderId(hldr)
+	}
+	return hldrs, nil
+}
+
+func (ctxb *ContextBuilder) assignHolderId(hldr *holder) {
+	ctxb.lastHolderId++
+	hldr.id = ctxb.lastHolderId
+	if !ctxb.noLocations {
+		hldr.location = callerLocation()
 	}
-	return hldr, nil
 }
@@ -69,3 +69,40 @@ func GetAllOrErr[T any](ctx *Context) ([]T, *Error) {
 	}
 	return result, nil
 }
+
+func GetAllNamed[T any](ctx *Context) map[string]T {
+	result, err := GetAllNamedOrErr[T](ctx)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func GetAllNamedOrErr[T any](ctx *Context) (map[string]T, *Error) {
+	ttype := genericTypeOf[T]()
+	objs, err := ctx.getAllNamedByRType(ttype)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]T, len(objs))
+	for name, obj := range objs {
+		if typed, ok := obj.(T); ok {
+			result[name] = typed
+		} else {
+			return nil, newInvalidTypeError(&name, reflect.TypeOf(obj), ttype)
+		}
+	}
+	return result, nil
+}
+
+func Has[T any](ctx *Context) bool {
+	return ctx.hasRType(genericTypeOf[T]())
+}
+
+func HasNamed[T any](ctx *Context, name string) bool {
+	holder := ctx.namedHolder(name)
+	if holder == nil {
+		return false
+	}
+	return holder.providesType.AssignableTo(genericTypeOf[T]())
+}
@@ -3,13 +3,17 @@ package di
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"strings"
 )
 
 var ErrSkippedDependency = errors.New("skipped dependency")
 
+type ErrType int
+
 const (
-	ErrTypeDependencyCreation = iota
+	ErrTypeDependencyCreation ErrType = iota
 	ErrTypeDuplicatedName
 	ErrTypeDuplicatedRegistration
 	ErrTypeMissingDependency
@@ -19,19 +23,84 @@ const (
 	ErrTypeDependencyInitialization
 	ErrTypeDependencyShutdown
 	ErrTypeLifecycle
+	ErrTypeUnreachableDependency
+	ErrTypeInvalidConfig
+	ErrTypeAmbiguousDependency
+	ErrTypeInvocation
+)
+
+func (t ErrType) String() string {
+	switch t {
+	case ErrTypeDependencyCreation:
+		return "dependency creation"
+	case ErrTypeDuplicatedName:
+		return "duplicated name"
+	case ErrTypeDuplicatedRegistration:
+		return "duplicated registration"
+	case ErrTypeMissingDependency:
+		return "missing dependency"
+	case ErrTypeInvalidType:
+		return "invalid type"
+	case ErrTypeInvalidConstructor:
+		return "invalid constructor"
+	case ErrTypeCyclicDependency:
+		return "cyclic dependency"
+	case ErrTypeDependencyInitialization:
+		return "dependency initialization"
+	case ErrTypeDependencyShutdown:
+		return "dependency shutdown"
+	case ErrTypeLifecycle:
+		return "lifecycle"
+	case ErrTypeUnreachableDependency:
+		return "unreachable dependency"
+	case ErrTypeInvalidConfig:
+		return "invalid config"
+	case ErrTypeAmbiguousDependency:
+		return "ambiguous dependency"
+	case ErrTypeInvocation:
+		return "invocation"
+	default:
+		return fmt.Sprintf("ErrType(%d)", int(t))
+	}
+}
+
+var (
+	ErrDependencyCreation       = newSentinelError(ErrTypeDependencyCreation)
+	ErrDuplicatedName           = newSentinelError(ErrTypeDuplicatedName)
+	ErrDuplicatedRegistration   = newSentinelError(ErrTypeDuplicatedRegistration)
+	ErrMissingDependency        = newSentinelError(ErrTypeMissingDependency)
+	ErrInvalidType              = newSentinelError(ErrTypeInvalidType)
+	ErrInvalidConstructor       = newSentinelError(ErrTypeInvalidConstructor)
+	ErrCyclicDependency         = newSentinelError(ErrTypeCyclicDependency)
+	ErrDependencyInitialization = newSentinelError(ErrTypeDependencyInitialization)
+	ErrDependencyShutdown       = newSentinelError(ErrTypeDependencyShutdown)
+	ErrLifecycle                = newSentinelError(ErrTypeLifecycle)
+	ErrUnreachableDependency    = newSentinelError(ErrTypeUnreachableDependency)
+	ErrInvalidConfig            = newSentinelError(ErrTypeInvalidConfig)
+	ErrAmbiguousDependency      = newSentinelError(ErrTypeAmbiguousDependency)
+	ErrInvocation               = newSentinelError(ErrTypeInvocation)
 )
 
 type Error struct {
-	errType int
-	message string
-	cause   error
+	errType     ErrType
+	message     string
+	cause       error
+	sentinel    bool
+	objType     reflect.Type
+	name        string
+	key         string
+	path        []string
+	cycle       []string
+	candidates  []string
+	suggestions []string
+	location    string
 }
 
-func (e *Error) ErrType() int {
+func (e *Error) ErrType() ErrType {
 	return e.errType
 }
 
-func (e *Error) IsErrType(errType int) bool {
+func (e *Error) IsErrType(errType ErrType) bool {
 	return e.errType == errType
 }
 
@@ -43,14 +112,106 @@ func (e *Error) Unwrap() error {
 	return e.cause
 }
 
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.sentinel && t.errType == e.errType
+}
+
+func (e *Error) Type() reflect.Type {
+	return e.objType
+}
+
+func (e *Error) Name() string {
+	return e.name
+}
+
+func (e *Error) Key() string {
+	return e.key
+}
+
+func (e *Error) Path() []string {
+	return copyStrings(e.path)
+}
+
+func (e *Error) Cycle() []string {
+	return copyStrings(e.cycle)
+}
+
+func (e *Error) Candidates() []string {
+	return copyStrings(e.candidates)
+}
+
+func (e *Error) Suggestions() []string {
+	return copyStrings(e.suggestions)
+}
+
+func (e *Error) Location() string {
+	return e.location
+}
+
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, e.message)
+		if !s.Flag('+') {
+			return
+		}
+		if e.location != "" {
+			fmt.Fprintf(s, "\nregistered at: %s", e.location)
+		}
+		var perr *PanicError
+		if errors.As(e, &perr) {
+			fmt.Fprintf(s, "\npanic stack:\n%s", perr.Stack)
+		}
+	case 's':
+		io.WriteString(s, e.message)
+	case 'q':
+		fmt.Fprintf(s, "%q", e.message)
+	default:
+		io.WriteString(s, e.message)
+	}
+}
+
 func (e *Error) RootCause() error {
-	if e.cause == nil {
+	cause := e.cause
+	if perr, ok := cause.(*PanicError); ok && perr.Unwrap() != nil {
+		cause = perr.Unwrap()
+	}
+	if cause == nil {
 		return e
 	}
-	if dierr, ok := e.cause.(*Error); ok {
+	if dierr, ok := cause.(*Error); ok {
 		return dierr.RootCause()
 	}
-	return e.cause
+	return cause
+}
+
+func (e *Error) withPath(path []string) *Error {
+	e.path = path
+	return e
+}
+
+func (e *Error) withLocation(location string) *Error {
+	if location != "" {
+		e.location = location
+	}
+	return e
+}
+
+func (e *Error) withSuggestions(suggestions []string) *Error {
+	if len(suggestions) > 0 {
+		e.message = fmt.Sprintf("%s, did you mean: %s", e.message, strings.Join(suggestions, ", "))
+		e.suggestions = suggestions
+	}
+	return e
+}
+
+func newSentinelError(errType ErrType) *Error {
+	return &Error{
+		errType:  errType,
+		message:  errType.String(),
+		sentinel: true,
+	}
 }
 
 func newLifecycleError(cause string) *Error {
@@ -68,13 +229,43 @@ func newDuplicatedRegistrationError() *Error {
 	}
 }
 
-func newDependencyCreationError(objName *string, objType *reflect.Type, cause error) *Error {
-	msg := fmt.Sprintf("could not create dependency %s, cause:\n%s", descriptor(objName, objType), cause)
+func newConflictingOptionsError() *Error {
 	return &Error{
+		errType: ErrTypeDuplicatedRegistration,
+		message: "duplicated registration with conflicting options",
+	}
+}
+
+func newDependencyCreationError(objName *string, objType *reflect.Type, hldr *holder, path []string, cause error) *Error {
+	if dierr := directCreationError(cause); dierr != nil {
+		return dierr
+	}
+	msg := fmt.Sprintf("could not create dependency %s: %s", strings.Join(path, " → "), cause)
+	if hldr.ctorType != nil {
+		msg = fmt.Sprintf("%s\nfailing constructor: %s", msg, hldr.ctorType)
+	}
+	err := &Error{
 		errType: ErrTypeDependencyCreation,
 		message: msg,
 		cause:   cause,
+		objType: derefType(objType),
+		name:    derefString(objName),
+		path:    path,
 	}
+	return err.withLocation(hldr.location)
+}
+
+// directCreationError returns cause if it is a creation error raised by a nested
+// dependency, either returned directly or panicked by a nested Get.
+// Errors wrapped by a constructor are not flattened so the wrapping is kept.
+func directCreationError(cause error) *Error {
+	if perr, ok := cause.(*PanicError); ok {
+		cause, _ = perr.Value.(error)
+	}
+	if dierr, ok := cause.(*Error); ok && dierr.errType == ErrTypeDependencyCreation {
+		return dierr
+	}
+	return nil
 }
 
 func newMissingDependencyError(objName *string, objType *reflect.Type) *Error {
@@ -82,6 +273,18 @@ func newMissingDependencyError(objName *string, objType *reflect.Type) *Error {
 	return &Error{
 		errType: ErrTypeMissingDependency,
 		message: msg,
+		objType: derefType(objType),
+		name:    derefString(objName),
+	}
+}
+
+func newAmbiguousDependencyError(objType reflect.Type, candidates []string) *Error {
+	msg := fmt.Sprintf("ambiguous dependency %s, candidates: %s", objType, strings.Join(candidates, ", "))
+	return &Error{
+		errType:    ErrTypeAmbiguousDependency,
+		message:    msg,
+		objType:    objType,
+		candidates: candidates,
 	}
 }
 
@@ -90,6 +293,17 @@ func newInvalidTypeError(objName *string, objType reflect.Type, expectedType ref
 	return &Error{
 		errType: ErrTypeInvalidType,
 		message: msg,
+		objType: expectedType,
+		name:    derefString(objName),
+	}
+}
+
+func newExpectedInterfaceError(objType reflect.Type) *Error {
+	msg := fmt.Sprintf("expected interface type, got %s", objType)
+	return &Error{
+		errType: ErrTypeInvalidType,
+		message: msg,
+		objType: objType,
 	}
 }
 
@@ -99,6 +313,7 @@ func newInitializationError(objType *reflect.Type, cause error) *Error {
 		errType: ErrTypeDependencyInitialization,
 		message: msg,
 		cause:   cause,
+		objType: derefType(objType),
 	}
 }
 
@@ -108,6 +323,7 @@ func newShutdownError(objType *reflect.Type, cause error) *Error {
 		errType: ErrTypeDependencyShutdown,
 		message: msg,
 		cause:   cause,
+		objType: derefType(objType),
 	}
 }
 
@@ -123,6 +339,7 @@ func newCyclicDependencyError(path []string) *Error {
 	return &Error{
 		errType: ErrTypeCyclicDependency,
 		message: msg,
+		cycle:   path,
 	}
 }
 
@@ -139,5 +356,81 @@ func newDuplicatedNameError(name string) *Error {
 	return &Error{
 		errType: ErrTypeDuplicatedName,
 		message: msg,
+		name:    name,
+	}
+}
+
+func newDuplicatedKeyError(key keyId) *Error {
+	msg := fmt.Sprintf("duplicated dependency key: %s", key)
+	return &Error{
+		errType: ErrTypeDuplicatedName,
+		message: msg,
+		objType: key.rtype,
+		name:    key.name,
+	}
+}
+
+func newCyclicAliasError(path []string) *Error {
+	msg := fmt.Sprintf("cyclic alias: %s", strings.Join(path, " → "))
+	return &Error{
+		errType: ErrTypeCyclicDependency,
+		message: msg,
+		name:    path[0],
+		cycle:   path,
+	}
+}
+
+func newDanglingAliasError(alias string, name string) *Error {
+	msg := fmt.Sprintf("dangling alias: %s → %s", alias, name)
+	return &Error{
+		errType: ErrTypeMissingDependency,
+		message: msg,
+		name:    alias,
+	}
+}
+
+func newUnreachableDependencyError(descriptors []string) *Error {
+	msg := fmt.Sprintf("unreachable dependencies: %s", strings.Join(descriptors, ", "))
+	return &Error{
+		errType:    ErrTypeUnreachableDependency,
+		message:    msg,
+		candidates: descriptors,
+	}
+}
+
+func newMissingConfigError(key string) *Error {
+	msg := fmt.Sprintf("missing config value: %s", key)
+	return &Error{
+		errType: ErrTypeInvalidConfig,
+		message: msg,
+		key:     key,
+	}
+}
+
+func newInvalidConfigError(key string, cause error) *Error {
+	msg := fmt.Sprintf("invalid config value: %s, cause:\n%s", key, cause)
+	return &Error{
+		errType: ErrTypeInvalidConfig,
+		message: msg,
+		cause:   cause,
+		key:     key,
+	}
+}
+
+func newInvalidInvocationError(cause string) *Error {
+	msg := fmt.Sprintf("invalid invocation: %s", cause)
+	return &Error{
+		errType: ErrTypeInvocation,
+		message: msg,
+	}
+}
+
+func newInvocationError(fnType reflect.Type, cause error) *Error {
+	msg := fmt.Sprintf("could not invoke function %s, cause:\n%s", fnType, cause)
+	return &Error{
+		errType: ErrTypeInvocation,
+		message: msg,
+		cause:   cause,
+		objType: fnType,
 	}
 }
@@ -1,97 +1,170 @@
 package di
 
 import (
-	"errors"
 	"reflect"
 )
 
 type ctor func(ctx *Context) (any, error)
 
+type Out struct{}
+
+var (
+	outRType   = reflect.TypeOf(Out{})
+	errorRType = reflect.TypeOf(new(error)).Elem()
+)
+
 type holder struct {
+	id           int
 	ctor         ctor
 	created      bool
 	instance     any
+	lazy         bool
+	eager        bool
+	phase        *int
+	params       []reflect.Type
+	profiles     [][]string
+	groups       []string
+	deps         []Dependency
+	outName      string
+	ctorType     reflect.Type
 	providesType reflect.Type
+	location     string
 }
 
-func newHolder(ctor any, lazy bool) (*holder, *Error) {
+func newHolders(ctor any, lazy bool) ([]*holder, *Error) {
 	ctype := reflect.TypeOf(ctor)
 	if ctype == nil {
 		return nil, newInvalidConstructorError("untyped constructor")
 	}
 	if lazy {
-		return createLazyHolder(ctor)
+		return createLazyHolders(ctor)
 	} else {
-		return createEagerHolder(ctor)
+		hldr, err := createEagerHolder(ctor)
+		if err != nil {
+			return nil, err
+		}
+		return []*holder{hldr}, nil
 	}
 }
 
-func createLazyHolder(ctor any) (*holder, *Error) {
+func createLazyHolders(ctor any) ([]*holder, *Error) {
 	ctype := reflect.TypeOf(ctor)
 	if ctype.Kind() != reflect.Func {
 		return nil, newInvalidConstructorError("expected constructor function")
 	}
 	cval := reflect.ValueOf(ctor)
 	numResults := ctype.NumOut()
-	if numResults < 1 || numResults > 2 {
-		return nil, newInvalidConstructorError("expected one result value with an optional error")
+	withError := numResults > 0 && ctype.Out(numResults-1) == errorRType
+	numValues := numResults
+	if withError {
+		numValues--
 	}
-	if ctype.IsVariadic() {
-		return nil, newInvalidConstructorError("variadic parameters not supported (use slice instead)")
+	if numValues < 1 {
+		return nil, newInvalidConstructorError("expected at least one result value with an optional error")
 	}
-	if numResults == 2 {
-		outtype := reflect.New(ctype.Out(1))
-		if _, ok := outtype.Interface().(*error); !ok {
-			return nil, newInvalidConstructorError("expected second result value to be an error")
+	for i := 0; i < numValues; i++ {
+		if ctype.Out(i) == errorRType {
+			return nil, newInvalidConstructorError("expected error to be the last result value")
 		}
 	}
-	resultType := ctype.Out(0)
 	numArgs := ctype.NumIn()
 	params := make([]reflect.Type, numArgs)
 	for i := 0; i < numArgs; i++ {
 		params[i] = ctype.In(i)
 	}
-	prov := func(ctx *Context) (any, error) {
-		args := make([]reflect.Value, numArgs)
-		for i, ptype := range params {
-			if ptype.Kind() == reflect.Slice {
-				argslice, err := ctx.getAllByRType(ptype.Elem())
-				if err != nil {
-					return nil, err
-				}
-				rslice := reflect.MakeSlice(ptype, 0, len(argslice))
-				for _, item := range argslice {
-					rslice = reflect.Append(rslice, reflect.ValueOf(item))
-				}
-				args[i] = rslice
-			} else if ptype == genericTypeOf[*Context]() {
-				args[i] = reflect.ValueOf(ctx)
-			} else {
-				arg, err := ctx.getByRType(ptype)
-				if err != nil {
-					return nil, err
-				}
-				args[i] = reflect.ValueOf(arg)
-			}
+	deps, err := paramDependencies(params)
+	if err != nil {
+		return nil, err
+	}
+	call := func(ctx *Context) ([]reflect.Value, error) {
+		args, err := resolveArgs(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		var result []reflect.Value
+		if ctype.IsVariadic() {
+			result = cval.CallSlice(args)
+		} else {
+			result = cval.Call(args)
 		}
-		result := cval.Call(args)
-		obj := result[0].Interface()
-		if len(result) == 2 {
-			if result[1].IsNil() {
-				return obj, nil
+		if withError && !result[numValues].IsNil() {
+			return nil, result[numValues].Interface().(error)
+		}
+		return result[:numValues], nil
+	}
+	if numValues == 1 && !isOutStruct(ctype.Out(0)) {
+		prov := func(ctx *Context) (any, error) {
+			result, err := call(ctx)
+			if err != nil {
+				return nil, err
 			}
-			err, ok := result[1].Interface().(error)
-			if !ok {
-				return nil, newInvalidConstructorError("expected second result value to be an error xxxx")
+			return result[0].Interface(), nil
+		}
+		return []*holder{{
+			ctor:         prov,
+			lazy:         true,
+			params:       params,
+			deps:         deps,
+			ctorType:     ctype,
+			providesType: ctype.Out(0),
+		}}, nil
+	}
+	source := &holder{
+		ctor: func(ctx *Context) (any, error) {
+			return call(ctx)
+		},
+		lazy:         true,
+		params:       params,
+		deps:         deps,
+		ctorType:     ctype,
+		providesType: ctype,
+	}
+	holders := make([]*holder, 0, numValues)
+	for i := 0; i < numValues; i++ {
+		rtype := ctype.Out(i)
+		if !isOutStruct(rtype) {
+			holders = append(holders, createProductHolder(source, rtype, "", i, -1))
+			continue
+		}
+		for j := 0; j < rtype.NumField(); j++ {
+			field := rtype.Field(j)
+			if field.Type == outRType || !field.IsExported() {
+				continue
 			}
-			return obj, err
+			holders = append(holders, createProductHolder(source, field.Type, field.Tag.Get("name"), i, j))
+		}
+	}
+	if len(holders) == 0 {
+		return nil, newInvalidConstructorError("expected constructor result to provide at least one dependency")
+	}
+	return holders, nil
+}
+
+func createProductHolder(source *holder, rtype reflect.Type, name string, result int, field int) *holder {
+	prov := func(ctx *Context) (any, error) {
+		obj, err := source.getOrCreate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		value := obj.([]reflect.Value)[result]
+		if field >= 0 {
+			value = value.Field(field)
 		}
-		return obj, nil
+		return value.Interface(), nil
 	}
 	return &holder{
 		ctor:         prov,
-		providesType: resultType,
-	}, nil
+		lazy:         true,
+		params:       source.params,
+		deps:         source.deps,
+		outName:      name,
+		ctorType:     source.ctorType,
+		providesType: rtype,
+	}
+}
+
+func isOutStruct(rtype reflect.Type) bool {
+	return embedsType(rtype, outRType)
 }
 
 func createEagerHolder(value any) (*holder, *Error) {
@@ -103,31 +176,51 @@ func createEagerHolder(value any) (*holder, *Error) {
 	}, nil
 }
 
+// instance holds the state of a holder in a single context,
+// so contexts built from the same builder do not share created objects.
+type instance struct {
+	value       any
+	created     bool
+	used        bool
+	initialized bool
+	shutdown    bool
+}
+
 func (h *holder) getOrCreate(ctx *Context) (any, error) {
-	obj := h.instance
-	if !h.created {
+	obj, err := h.create(ctx)
+	if err != nil {
+		return empty[any](), err
+	}
+	ctx.instanceOf(h).used = true
+	return obj, nil
+}
+
+// create returns the instance without marking it as used,
+// so lifecycle-driven creation is not reported as usage.
+func (h *holder) create(ctx *Context) (any, error) {
+	inst := ctx.instanceOf(h)
+	if !inst.created {
 		newobj, err := provide(ctx, h)
 		if err != nil {
 			return empty[any](), err
 		}
-		h.instance = newobj
-		h.created = true
-		obj = newobj
+		inst.value = newobj
+		inst.created = true
+		if ctx.initialized {
+			if err := ctx.initializeInstance(h); err != nil {
+				// do not cache an instance that failed to initialize
+				*inst = instance{}
+				return empty[any](), err
+			}
+		}
 	}
-	return obj, nil
+	return inst.value, nil
 }
 
 func provide(ctx *Context, holder *holder) (result any, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			switch x := r.(type) {
-			case string:
-				err = errors.New(x)
-			case error:
-				err = x
-			default:
-				err = errors.New("ctor panic")
-			}
+			err = recoverPanic(ctx, r)
 			result = empty[any]()
 		}
 	}()
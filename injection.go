@@ -0,0 +1,185 @@
+package di
+
+import (
+	"reflect"
+)
+
+type In struct{}
+
+type Dependency struct {
+	Type     reflect.Type
+	Name     string
+	Group    string
+	Optional bool
+	Multiple bool
+	ByName   bool
+	mapType  reflect.Type
+}
+
+var (
+	inRType      = reflect.TypeOf(In{})
+	contextRType = reflect.TypeOf(&Context{})
+)
+
+func paramDependencies(params []reflect.Type) ([]Dependency, *Error) {
+	deps := make([]Dependency, 0, len(params))
+	for _, ptype := range params {
+		if ptype == contextRType {
+			continue
+		}
+		if !isInStruct(ptype) {
+			deps = append(deps, typeDependency(ptype))
+			continue
+		}
+		for i := 0; i < ptype.NumField(); i++ {
+			field := ptype.Field(i)
+			if field.Type == inRType || !field.IsExported() || field.Type == contextRType {
+				continue
+			}
+			dep, err := fieldDependency(field)
+			if err != nil {
+				return nil, err
+			}
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+func typeDependency(rtype reflect.Type) Dependency {
+	if rtype.Kind() == reflect.Slice {
+		return Dependency{Type: rtype.Elem(), Multiple: true}
+	}
+	if rtype.Kind() == reflect.Map && rtype.Key().Kind() == reflect.String {
+		return Dependency{Type: rtype.Elem(), Multiple: true, ByName: true, mapType: rtype}
+	}
+	return Dependency{Type: rtype}
+}
+
+func fieldDependency(field reflect.StructField) (Dependency, *Error) {
+	dep := typeDependency(field.Type)
+	dep.Name = field.Tag.Get("name")
+	dep.Group = field.Tag.Get("group")
+	dep.Optional = field.Tag.Get("optional") == "true"
+	if dep.Name != "" {
+		dep.Type = field.Type
+		dep.Multiple = false
+		dep.ByName = false
+	}
+	if dep.Group != "" && dep.ByName {
+		return dep, newInvalidConstructorError("expected group field " + field.Name + " to be a slice")
+	}
+	if dep.Group != "" && field.Type.Kind() != reflect.Slice {
+		return dep, newInvalidConstructorError("expected group field " + field.Name + " to be a slice")
+	}
+	if dep.Group != "" && dep.Name != "" {
+		return dep, newInvalidConstructorError("expected field " + field.Name + " to define name or group, not both")
+	}
+	return dep, nil
+}
+
+func resolveArgs(ctx *Context, params []reflect.Type) ([]reflect.Value, *Error) {
+	args := make([]reflect.Value, len(params))
+	for i, ptype := range params {
+		if isInStruct(ptype) {
+			arg, err := resolveInStruct(ctx, ptype)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		} else {
+			arg, err := resolveValue(ctx, ptype, typeDependency(ptype))
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+	}
+	return args, nil
+}
+
+func resolveInStruct(ctx *Context, rtype reflect.Type) (reflect.Value, *Error) {
+	result := reflect.New(rtype).Elem()
+	for i := 0; i < rtype.NumField(); i++ {
+		field := rtype.Field(i)
+		if field.Type == inRType || !field.IsExported() {
+			continue
+		}
+		dep, err := fieldDependency(field)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value, err := resolveValue(ctx, field.Type, dep)
+		if err != nil {
+			if dep.Optional && err.IsErrType(ErrTypeMissingDependency) {
+				continue
+			}
+			return reflect.Value{}, err
+		}
+		result.Field(i).Set(value)
+	}
+	return result, nil
+}
+
+func resolveValue(ctx *Context, rtype reflect.Type, dep Dependency) (reflect.Value, *Error) {
+	if rtype == contextRType {
+		return reflect.ValueOf(ctx), nil
+	}
+	if dep.Name != "" {
+		obj, err := ctx.GetNamedOrErr(dep.Name)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value := reflect.ValueOf(obj)
+		if !value.Type().AssignableTo(rtype) {
+			return reflect.Value{}, newInvalidTypeError(&dep.Name, value.Type(), rtype)
+		}
+		return value, nil
+	}
+	if ctx.hasRegisteredMap(dep) {
+		dep = Dependency{Type: rtype}
+	}
+	if dep.ByName {
+		objs, err := ctx.getAllNamedByRType(dep.Type)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rmap := reflect.MakeMapWithSize(rtype, len(objs))
+		for name, item := range objs {
+			rmap.SetMapIndex(reflect.ValueOf(name).Convert(rtype.Key()), reflect.ValueOf(item))
+		}
+		return rmap, nil
+	}
+	if dep.Multiple {
+		var objs []any
+		var err *Error
+		if dep.Group != "" {
+			objs, err = ctx.getGroupByRType(dep.Group, dep.Type)
+		} else {
+			objs, err = ctx.getAllByRType(dep.Type)
+		}
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rslice := reflect.MakeSlice(rtype, 0, len(objs))
+		for _, item := range objs {
+			rslice = reflect.Append(rslice, reflect.ValueOf(item))
+		}
+		return rslice, nil
+	}
+	obj, err := ctx.getByRType(rtype)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(obj), nil
+}
+
+// hasRegisteredMap reports whether a map dependency is registered by its own type,
+// which takes precedence over the map of named dependencies.
+func (ctx *Context) hasRegisteredMap(dep Dependency) bool {
+	return dep.ByName && dep.mapType != nil && ctx.hasRType(dep.mapType)
+}
+
+func isInStruct(rtype reflect.Type) bool {
+	return embedsType(rtype, inRType)
+}
@@ -0,0 +1,164 @@
+package di
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type Registration struct {
+	Type          reflect.Type
+	Types         []reflect.Type
+	Names         []string
+	Keys          []string
+	Aliases       []string
+	Lazy          bool
+	Eager         bool
+	Created       bool
+	Used          bool
+	Initializable bool
+	Shutdownable  bool
+	Phase         int
+	Params        []reflect.Type
+	Dependencies  []Dependency
+	Groups        []string
+	Location      string
+}
+
+func (ctx *Context) Registrations() []Registration {
+	return ctx.registrations(ctx.holders())
+}
+
+func (ctx *Context) UnusedRegistrations() []Registration {
+	unused := make([]*holder, 0)
+	for _, hldr := range ctx.holders() {
+		if !ctx.instanceOf(hldr).used {
+			unused = append(unused, hldr)
+		}
+	}
+	return ctx.registrations(unused)
+}
+
+func (ctx *Context) registrations(holders []*holder) []Registration {
+	typesByHolder := make(map[*holder][]reflect.Type)
+	for rtype, hldrs := range ctx.holdersByType {
+		for _, hldr := range hldrs {
+			typesByHolder[hldr] = append(typesByHolder[hldr], rtype)
+		}
+	}
+	namesByHolder := make(map[*holder][]string)
+	for name, hldr := range ctx.holdersByName {
+		namesByHolder[hldr] = append(namesByHolder[hldr], name)
+	}
+	keysByHolder := make(map[*holder][]string)
+	for key, hldr := range ctx.holdersByKey {
+		keysByHolder[hldr] = append(keysByHolder[hldr], key.name)
+	}
+	aliasesByHolder := make(map[*holder][]string)
+	for name, a := range ctx.aliases {
+		hldr := ctx.holdersByName[a.name]
+		aliasesByHolder[hldr] = append(aliasesByHolder[hldr], name)
+	}
+	result := make([]Registration, len(holders))
+	for i, hldr := range holders {
+		types := typesByHolder[hldr]
+		sort.Slice(types, func(i, j int) bool {
+			return types[i].String() < types[j].String()
+		})
+		names := namesByHolder[hldr]
+		sort.Strings(names)
+		keys := keysByHolder[hldr]
+		sort.Strings(keys)
+		aliases := aliasesByHolder[hldr]
+		sort.Strings(aliases)
+		params := make([]reflect.Type, len(hldr.params))
+		copy(params, hldr.params)
+		deps := make([]Dependency, len(hldr.deps))
+		copy(deps, hldr.deps)
+		inst := ctx.instanceOf(hldr)
+		groups := make([]string, len(hldr.groups))
+		copy(groups, hldr.groups)
+		result[i] = Registration{
+			Type:          hldr.providesType,
+			Types:         types,
+			Names:         names,
+			Keys:          keys,
+			Aliases:       aliases,
+			Lazy:          hldr.lazy,
+			Eager:         hldr.eager,
+			Created:       inst.created,
+			Used:          inst.used,
+			Initializable: ctx.implements(hldr, initializableRType),
+			Shutdownable:  ctx.implements(hldr, shutdownableRType),
+			Phase:         ctx.phaseOf(hldr),
+			Params:        params,
+			Dependencies:  deps,
+			Groups:        groups,
+			Location:      hldr.location,
+		}
+	}
+	return result
+}
+
+func (ctx *Context) HasType(atype any) bool {
+	rtype := reflect.TypeOf(atype).Elem()
+	return ctx.hasRType(rtype)
+}
+
+func (ctx *Context) HasNamed(name string) bool {
+	return ctx.namedHolder(name) != nil
+}
+
+func (ctx *Context) hasRType(rtype reflect.Type) bool {
+	if len(ctx.holdersByType[rtype]) > 0 {
+		return true
+	}
+	if ctx.implicitIfaces && rtype.Kind() == reflect.Interface {
+		return len(ctx.implementingHolders(rtype)) == 1
+	}
+	return false
+}
+
+func (ctx *Context) holders() []*holder {
+	unique := make(map[*holder]struct{})
+	for _, hldrs := range ctx.holdersByType {
+		for _, hldr := range hldrs {
+			unique[hldr] = struct{}{}
+		}
+	}
+	for _, hldr := range ctx.holdersByName {
+		unique[hldr] = struct{}{}
+	}
+	result := make([]*holder, 0, len(unique))
+	for hldr := range unique {
+		result = append(result, hldr)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func (ctx *Context) holderDescriptor(hldr *holder) string {
+	names := make([]string, 0)
+	for name, h := range ctx.holdersByName {
+		if h == hldr {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return descriptor(nil, &hldr.providesType)
+	}
+	sort.Strings(names)
+	name := strings.Join(names, ", ")
+	return descriptor(&name, &hldr.providesType)
+}
+
+func (ctx *Context) implements(hldr *holder, iface reflect.Type) bool {
+	for _, t := range ctx.holderTypes(hldr) {
+		if t.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package di
+
+import (
+	"reflect"
+)
+
+type invocation struct {
+	fn     reflect.Value
+	params []reflect.Type
+	deps   []Dependency
+	onInit bool
+}
+
+func (ctxb *ContextBuilder) Invoke(fn any) {
+	if err := ctxb.InvokeOrErr(fn); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) InvokeOrErr(fn any) *Error {
+	return ctxb.addInvocation(fn, false)
+}
+
+func (ctxb *ContextBuilder) InvokeOnInit(fn any) {
+	if err := ctxb.InvokeOnInitOrErr(fn); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) InvokeOnInitOrErr(fn any) *Error {
+	return ctxb.addInvocation(fn, true)
+}
+
+func (ctxb *ContextBuilder) addInvocation(fn any, onInit bool) *Error {
+	inv, err := newInvocation(fn, onInit)
+	if err != nil {
+		return ctxb.withCallerLocation(err)
+	}
+	ctxb.invocations = append(ctxb.invocations, inv)
+	return nil
+}
+
+func newInvocation(fn any, onInit bool) (*invocation, *Error) {
+	ftype := reflect.TypeOf(fn)
+	if ftype == nil || ftype.Kind() != reflect.Func {
+		return nil, newInvalidInvocationError("expected function")
+	}
+	if ftype.NumOut() > 1 || (ftype.NumOut() == 1 && ftype.Out(0) != errorRType) {
+		return nil, newInvalidInvocationError("expected no result value or a single error")
+	}
+	params := make([]reflect.Type, ftype.NumIn())
+	for i := range params {
+		params[i] = ftype.In(i)
+	}
+	deps, err := paramDependencies(params)
+	if err != nil {
+		return nil, err
+	}
+	return &invocation{
+		fn:     reflect.ValueOf(fn),
+		params: params,
+		deps:   deps,
+		onInit: onInit,
+	}, nil
+}
+
+func (inv *invocation) invoke(ctx *Context) *Error {
+	args, err := resolveArgs(ctx, inv.params)
+	if err != nil {
+		return newInvocationError(inv.fn.Type(), err)
+	}
+	if cerr := inv.call(ctx, args); cerr != nil {
+		return newInvocationError(inv.fn.Type(), cerr)
+	}
+	return nil
+}
+
+func (inv *invocation) call(ctx *Context, args []reflect.Value) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverPanic(ctx, r)
+		}
+	}()
+	var result []reflect.Value
+	if inv.fn.Type().IsVariadic() {
+		result = inv.fn.CallSlice(args)
+	} else {
+		result = inv.fn.Call(args)
+	}
+	if len(result) == 1 && !result[0].IsNil() {
+		return result[0].Interface().(error)
+	}
+	return nil
+}
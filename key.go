@@ -0,0 +1,165 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Key identifies a dependency by a name and a type.
+// The same name may be used by keys of different types.
+type Key[T any] struct {
+	name string
+}
+
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+func (k Key[T]) String() string {
+	return k.id().String()
+}
+
+func (k Key[T]) id() keyId {
+	return keyId{name: k.name, rtype: genericTypeOf[T]()}
+}
+
+type keyId struct {
+	name  string
+	rtype reflect.Type
+}
+
+func (k keyId) String() string {
+	return fmt.Sprintf("%s (key: %s)", k.rtype, k.name)
+}
+
+func AddKeyed[T any](ctxb *ContextBuilder, key Key[T], value T, opts ...Option) {
+	if err := AddKeyedOrErr(ctxb, key, value, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func AddKeyedOrErr[T any](ctxb *ContextBuilder, key Key[T], value T, opts ...Option) *Error {
+	return ctxb.addKeyedOrErr(key.id(), value, false, opts)
+}
+
+func ProvideKeyed[T any](ctxb *ContextBuilder, key Key[T], ctor any, opts ...Option) {
+	if err := ProvideKeyedOrErr(ctxb, key, ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func ProvideKeyedOrErr[T any](ctxb *ContextBuilder, key Key[T], ctor any, opts ...Option) *Error {
+	return ctxb.addKeyedOrErr(key.id(), ctor, true, opts)
+}
+
+func GetKeyed[T any](ctx *Context, key Key[T]) T {
+	obj, err := GetKeyedOrErr(ctx, key)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func GetKeyedOrErr[T any](ctx *Context, key Key[T]) (T, *Error) {
+	obj, err := ctx.getByKey(key.id())
+	if err != nil {
+		return empty[T](), err
+	}
+	typed, ok := obj.(T)
+	if !ok {
+		return empty[T](), newInvalidTypeError(&key.name, reflect.TypeOf(obj), genericTypeOf[T]())
+	}
+	return typed, nil
+}
+
+func HasKeyed[T any](ctx *Context, key Key[T]) bool {
+	return ctx.holdersByKey[key.id()] != nil
+}
+
+func (ctxb *ContextBuilder) addKeyedOrErr(key keyId, ctor any, lazy bool, opts []Option) (err *Error) {
+	defer func() { err = ctxb.withCallerLocation(err) }()
+	hldr, err := createUniqueHolder(ctxb, ctor, lazy, opts)
+	if err != nil {
+		return err
+	}
+	keyed := ctxb.hasHolderForKey(hldr, key)
+	err = ctxb.addHolderForKey(hldr, key)
+	if err != nil {
+		return err
+	}
+	err = ctxb.addHolderForType(hldr, key.rtype)
+	if err != nil {
+		if !keyed {
+			ctxb.removeHolderForKey(hldr, key)
+		}
+		return err
+	}
+	if err := ctxb.addHolderForInterfaces(hldr, ctxb.exposedInterfaces); err != nil {
+		return err
+	}
+	return ctxb.addHolderForInterfaces(hldr, lifecycleRTypes)
+}
+
+func (ctxb *ContextBuilder) addHolderForKey(hldr *holder, key keyId) *Error {
+	for _, h := range ctxb.holdersByKey[key] {
+		if h == hldr {
+			return nil
+		}
+		if isConflictingHolder(h, hldr) {
+			return newDuplicatedKeyError(key)
+		}
+	}
+	ctxb.holdersByKey[key] = append(ctxb.holdersByKey[key], hldr)
+	return nil
+}
+
+func (ctxb *ContextBuilder) hasHolderForKey(hldr *holder, key keyId) bool {
+	for _, h := range ctxb.holdersByKey[key] {
+		if h == hldr {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctxb *ContextBuilder) removeHolderForKey(hldr *holder, key keyId) {
+	holders := ctxb.holdersByKey[key]
+	for i, h := range holders {
+		if h == hldr {
+			ctxb.holdersByKey[key] = append(holders[:i:i], holders[i+1:]...)
+			break
+		}
+	}
+	if len(ctxb.holdersByKey[key]) == 0 {
+		delete(ctxb.holdersByKey, key)
+	}
+}
+
+func (ctx *Context) getByKey(key keyId) (any, *Error) {
+	if ctx.shutdown {
+		return nil, newLifecycleError("context already shutdown")
+	}
+	holder := ctx.holdersByKey[key]
+	if holder == nil {
+		return empty[any](), newMissingDependencyError(&key.name, &key.rtype).
+			withPath(ctx.resolutionPath(key.String()))
+	}
+	depCtx, err := dependencyContext(ctx, key.String())
+	if err != nil {
+		return empty[any](), err
+	}
+	obj, cerr := holder.getOrCreate(depCtx)
+	if cerr != nil {
+		if !errors.Is(cerr, ErrSkippedDependency) {
+			return empty[any](), newDependencyCreationError(&key.name, &key.rtype, holder, depCtx.resolutionPath(""), cerr)
+		}
+		return empty[any](), newMissingDependencyError(&key.name, &key.rtype).
+			withPath(ctx.resolutionPath(key.String()))
+	}
+	return obj, nil
+}
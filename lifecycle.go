@@ -3,6 +3,7 @@ package di
 import (
 	stdcontext "context"
 	"reflect"
+	"sort"
 )
 
 type Shutdownable interface {
@@ -13,9 +14,125 @@ type Initializable interface {
 	Initialize()
 }
 
+// Phased defines the lifecycle phase of a dependency.
+// Initialize runs phases in ascending order and Shutdown in descending order.
+// Dependencies without a phase belong to phase 0.
+type Phased interface {
+	Phase() int
+}
+
 var (
 	initializableType  = new(Initializable)
 	initializableRType = reflect.TypeOf(initializableType).Elem()
 	shutdownableType   = new(Shutdownable)
 	shutdownableRType  = reflect.TypeOf(shutdownableType).Elem()
 )
+
+var lifecycleRTypes = []reflect.Type{initializableRType, shutdownableRType}
+
+// initializeInstances initializes every created instance implementing Initializable,
+// no matter how it was registered.
+func (ctx *Context) initializeInstances() *Error {
+	for _, hldr := range ctx.holdersByPhase(false) {
+		if err := ctx.initializeInstance(hldr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initializeInstance initializes a created instance once,
+// even if the same object is registered more than once.
+func (ctx *Context) initializeInstance(hldr *holder) *Error {
+	inst := ctx.instanceOf(hldr)
+	initializable, ok := inst.value.(Initializable)
+	if !inst.created || !ok || inst.initialized {
+		return nil
+	}
+	inst.initialized = true
+	if ctx.hasTwinInstance(inst, func(other *instance) bool { return other.initialized }) {
+		return nil
+	}
+	err := func() (suberr error) {
+		defer func() {
+			if r := recover(); r != nil {
+				suberr = recoverPanic(ctx, r)
+			}
+		}()
+		initializable.Initialize()
+		return nil
+	}()
+	if err != nil {
+		inst.initialized = false
+		depType := reflect.TypeOf(inst.value)
+		return newInitializationError(&depType, err).withLocation(hldr.location)
+	}
+	return nil
+}
+
+// shutdownInstances shuts down every created instance implementing Shutdownable,
+// no matter how it was registered. The same object registered more than once is shut down once.
+func (ctx *Context) shutdownInstances(context stdcontext.Context) *Error {
+	for _, hldr := range ctx.holdersByPhase(true) {
+		inst := ctx.instanceOf(hldr)
+		shutdownable, ok := inst.value.(Shutdownable)
+		if !inst.created || !ok || inst.shutdown {
+			continue
+		}
+		inst.shutdown = true
+		if ctx.hasTwinInstance(inst, func(other *instance) bool { return other.shutdown }) {
+			continue
+		}
+		err := func() (suberr error) {
+			defer func() {
+				if r := recover(); r != nil {
+					suberr = recoverPanic(ctx, r)
+				}
+			}()
+			shutdownable.Shutdown(context)
+			return nil
+		}()
+		if err != nil {
+			depType := reflect.TypeOf(inst.value)
+			return newShutdownError(&depType, err).withLocation(hldr.location)
+		}
+	}
+	return nil
+}
+
+func (ctx *Context) holdersByPhase(descending bool) []*holder {
+	holders := ctx.holders()
+	phases := make(map[*holder]int, len(holders))
+	for _, hldr := range holders {
+		phases[hldr] = ctx.phaseOf(hldr)
+	}
+	sort.SliceStable(holders, func(i, j int) bool {
+		if descending {
+			return phases[holders[i]] > phases[holders[j]]
+		}
+		return phases[holders[i]] < phases[holders[j]]
+	})
+	return holders
+}
+
+func (ctx *Context) phaseOf(hldr *holder) int {
+	if hldr.phase != nil {
+		return *hldr.phase
+	}
+	if phased, ok := ctx.instanceOf(hldr).value.(Phased); ok {
+		return phased.Phase()
+	}
+	return 0
+}
+
+func (ctx *Context) hasTwinInstance(inst *instance, matches func(other *instance) bool) bool {
+	if reflect.TypeOf(inst.value).Kind() != reflect.Pointer {
+		return false
+	}
+	for _, other := range ctx.instances {
+		if other != inst && other.created && other.value == inst.value && matches(other) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+var pkgPrefix = reflect.TypeOf(Context{}).PkgPath() + "."
+
+// callerLocation returns file and line of the first caller outside of this package.
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) && !strings.HasPrefix(frame.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func (ctxb *ContextBuilder) withCallerLocation(err *Error) *Error {
+	if err == nil || err.location != "" || ctxb.noLocations {
+		return err
+	}
+	return err.withLocation(callerLocation())
+}
@@ -0,0 +1,257 @@
+package di
+
+import (
+	"sort"
+
+	coll "github.com/coditory/go-di/internal/collection"
+)
+
+// ConflictPolicy decides what happens when merged builders
+// register the same name, key or alias.
+type ConflictPolicy int
+
+const (
+	ConflictFail ConflictPolicy = iota
+	ConflictKeepFirst
+	ConflictOverride
+)
+
+func (ctxb *ContextBuilder) Merge(other *ContextBuilder, policy ConflictPolicy) {
+	if err := ctxb.MergeOrErr(other, policy); err != nil {
+		panic(err)
+	}
+}
+
+func (ctxb *ContextBuilder) MergeOrErr(other *ContextBuilder, policy ConflictPolicy) *Error {
+	shared := ctxb.sharedHolders(other)
+	conflicts, err := ctxb.mergeConflicts(other, shared, policy)
+	if err != nil {
+		return ctxb.withCallerLocation(err)
+	}
+	for _, hldr := range conflicts.existing {
+		ctxb.removeHolder(hldr)
+	}
+	merged := make(map[*holder]*holder)
+	for h, hldr := range shared {
+		merged[h] = hldr
+	}
+	for _, h := range other.holders() {
+		if merged[h] != nil || conflicts.incoming[h] {
+			continue
+		}
+		hldr := *h
+		ctxb.lastHolderId++
+		hldr.id = ctxb.lastHolderId
+		merged[h] = &hldr
+	}
+	for ptr, hldrs := range other.holdersByCtors {
+		if ctxb.holdersByCtors[ptr] != nil {
+			continue
+		}
+		mhldrs := make([]*holder, 0, len(hldrs))
+		for _, h := range hldrs {
+			if !conflicts.incoming[h] {
+				mhldrs = append(mhldrs, merged[h])
+			}
+		}
+		if len(mhldrs) == len(hldrs) {
+			ctxb.holdersByCtors[ptr] = mhldrs
+		}
+	}
+	for rtype, set := range other.holdersByType {
+		for _, h := range set.ToSlice() {
+			if conflicts.incoming[h] {
+				continue
+			}
+			if ctxb.holdersByType[rtype] == nil {
+				ctxb.holdersByType[rtype] = coll.NewSet[*holder]()
+			}
+			if !ctxb.holdersByType[rtype].Contains(merged[h]) {
+				ctxb.holdersByType[rtype].Add(merged[h])
+			}
+		}
+	}
+	for name, hldrs := range other.holdersByName {
+		for _, h := range hldrs {
+			if !conflicts.incoming[h] && !ctxb.hasHolderForName(merged[h], name) {
+				ctxb.holdersByName[name] = append(ctxb.holdersByName[name], merged[h])
+			}
+		}
+	}
+	for key, hldrs := range other.holdersByKey {
+		for _, h := range hldrs {
+			if !conflicts.incoming[h] && !ctxb.hasHolderForKey(merged[h], key) {
+				ctxb.holdersByKey[key] = append(ctxb.holdersByKey[key], merged[h])
+			}
+		}
+	}
+	for _, h := range other.configHolders {
+		if !conflicts.incoming[h] && shared[h] == nil {
+			ctxb.configHolders = append(ctxb.configHolders, merged[h])
+		}
+	}
+	for name, a := range other.aliases {
+		if _, ok := ctxb.aliases[name]; !ok || policy == ConflictOverride {
+			ctxb.aliases[name] = a
+		}
+	}
+	if ctxb.deprecatedAliasHook == nil {
+		ctxb.deprecatedAliasHook = other.deprecatedAliasHook
+	}
+	ctxb.profiles = append(ctxb.profiles, other.profiles...)
+	if err := ctxb.exposeInterfaces(other.exposedInterfaces); err != nil {
+		return ctxb.withCallerLocation(err)
+	}
+	ctxb.implicitIfaces = ctxb.implicitIfaces || other.implicitIfaces
+	ctxb.lazyInit = ctxb.lazyInit || other.lazyInit
+	ctxb.repanic = ctxb.repanic || other.repanic
+	ctxb.noLocations = ctxb.noLocations || other.noLocations
+	ctxb.roots = append(ctxb.roots, other.roots...)
+	ctxb.configSources = append(ctxb.configSources, other.configSources...)
+	ctxb.invocations = append(ctxb.invocations, other.invocations...)
+	return nil
+}
+
+// sharedHolders maps holders created from the same constructor
+// in both builders, so they are merged as a single registration.
+func (ctxb *ContextBuilder) sharedHolders(other *ContextBuilder) map[*holder]*holder {
+	result := make(map[*holder]*holder)
+	for ptr, hldrs := range other.holdersByCtors {
+		existing := ctxb.holdersByCtors[ptr]
+		if len(existing) != len(hldrs) {
+			continue
+		}
+		for i, h := range hldrs {
+			result[h] = existing[i]
+		}
+	}
+	return result
+}
+
+type mergeConflicts struct {
+	existing []*holder
+	incoming map[*holder]bool
+}
+
+func (ctxb *ContextBuilder) mergeConflicts(other *ContextBuilder, shared map[*holder]*holder, policy ConflictPolicy) (mergeConflicts, *Error) {
+	conflicts := mergeConflicts{incoming: make(map[*holder]bool)}
+	existing := make(map[*holder]bool)
+	addConflict := func(h *holder, hldrs []*holder) bool {
+		conflicting := false
+		for _, e := range hldrs {
+			if isConflictingHolder(e, h) && shared[h] != e {
+				conflicting = true
+				if policy == ConflictOverride && !existing[e] {
+					existing[e] = true
+					conflicts.existing = append(conflicts.existing, e)
+				}
+			}
+		}
+		if conflicting && policy == ConflictKeepFirst {
+			conflicts.incoming[h] = true
+		}
+		return conflicting
+	}
+	for _, name := range sortedKeys(other.holdersByName) {
+		for _, h := range other.holdersByName[name] {
+			if addConflict(h, ctxb.holdersByName[name]) && policy == ConflictFail {
+				return conflicts, newDuplicatedNameError(name)
+			}
+		}
+	}
+	for _, key := range sortedKeyIds(other.holdersByKey) {
+		for _, h := range other.holdersByKey[key] {
+			if addConflict(h, ctxb.holdersByKey[key]) && policy == ConflictFail {
+				return conflicts, newDuplicatedKeyError(key)
+			}
+		}
+	}
+	if policy == ConflictFail {
+		for _, name := range sortedKeys(other.aliases) {
+			if a, ok := ctxb.aliases[name]; ok && a != other.aliases[name] {
+				return conflicts, newDuplicatedNameError(name)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+func (ctxb *ContextBuilder) removeHolder(hldr *holder) {
+	for ptr, hldrs := range ctxb.holdersByCtors {
+		for _, h := range hldrs {
+			if h == hldr {
+				delete(ctxb.holdersByCtors, ptr)
+				break
+			}
+		}
+	}
+	for rtype, set := range ctxb.holdersByType {
+		set.Remove(hldr)
+		if len(set.ToSlice()) == 0 {
+			delete(ctxb.holdersByType, rtype)
+		}
+	}
+	for name := range ctxb.holdersByName {
+		ctxb.removeHolderForName(hldr, name)
+	}
+	for key := range ctxb.holdersByKey {
+		ctxb.removeHolderForKey(hldr, key)
+	}
+	for i, h := range ctxb.configHolders {
+		if h == hldr {
+			ctxb.configHolders = append(ctxb.configHolders[:i:i], ctxb.configHolders[i+1:]...)
+			break
+		}
+	}
+}
+
+func (ctxb *ContextBuilder) holders() []*holder {
+	unique := make(map[*holder]struct{})
+	for _, set := range ctxb.holdersByType {
+		for _, hldr := range set.ToSlice() {
+			unique[hldr] = struct{}{}
+		}
+	}
+	for _, hldrs := range ctxb.holdersByName {
+		for _, hldr := range hldrs {
+			unique[hldr] = struct{}{}
+		}
+	}
+	for _, hldrs := range ctxb.holdersByKey {
+		for _, hldr := range hldrs {
+			unique[hldr] = struct{}{}
+		}
+	}
+	result := make([]*holder, 0, len(unique))
+	for hldr := range unique {
+		result = append(result, hldr)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func isConflictingHolder(existing *holder, hldr *holder) bool {
+	return existing != hldr && (len(existing.profiles) == 0 || len(hldr.profiles) == 0)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedKeyIds[V any](m map[keyId]V) []keyId {
+	result := make([]keyId, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
@@ -0,0 +1,45 @@
+package di
+
+type Option func(hldr *holder)
+
+type Module func(ctxb *ContextBuilder)
+
+func Profile(profiles ...string) Option {
+	return func(hldr *holder) {
+		hldr.profiles = append(hldr.profiles, profiles)
+	}
+}
+
+// Eager creates a provided dependency during Build instead of on first use.
+func Eager() Option {
+	return func(hldr *holder) {
+		hldr.eager = true
+	}
+}
+
+// Phase sets the lifecycle phase of a dependency.
+// It takes precedence over the phase returned by Phased.
+func Phase(phase int) Option {
+	return func(hldr *holder) {
+		hldr.phase = &phase
+	}
+}
+
+func Group(groups ...string) Option {
+	return func(hldr *holder) {
+		hldr.groups = append(hldr.groups, groups...)
+	}
+}
+
+func splitOptions(args []any) ([]any, []Option) {
+	rest := make([]any, 0, len(args))
+	opts := make([]Option, 0)
+	for _, arg := range args {
+		if opt, ok := arg.(Option); ok {
+			opts = append(opts, opt)
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	return rest, opts
+}
@@ -0,0 +1,44 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError holds a value recovered from a panic in a constructor,
+// lifecycle hook or invocation, together with the stack trace of the panic.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	switch x := e.Value.(type) {
+	case string:
+		return x
+	case error:
+		return x.Error()
+	default:
+		return fmt.Sprintf("panic: %v", x)
+	}
+}
+
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+func recoverPanic(ctx *Context, r any) error {
+	err, ok := r.(error)
+	skipped := ok && errors.Is(err, ErrSkippedDependency)
+	if ctx.repanic && !skipped {
+		panic(r)
+	}
+	return &PanicError{
+		Value: r,
+		Stack: debug.Stack(),
+	}
+}
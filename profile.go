@@ -0,0 +1,77 @@
+package di
+
+import (
+	"os"
+	"strings"
+)
+
+const ProfilesEnvVar = "DI_PROFILES"
+
+func (ctx *Context) ActiveProfiles() []string {
+	result := make([]string, len(ctx.activeProfiles))
+	copy(result, ctx.activeProfiles)
+	return result
+}
+
+func (ctx *Context) IsProfileActive(profile string) bool {
+	for _, p := range ctx.activeProfiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
+
+func activeProfiles(profiles []string) []string {
+	candidates := profiles
+	if env := os.Getenv(ProfilesEnvVar); env != "" {
+		candidates = append(candidates[:len(candidates):len(candidates)], strings.Split(env, ",")...)
+	}
+	result := make([]string, 0, len(candidates))
+	unique := make(map[string]bool)
+	for _, profile := range candidates {
+		profile = strings.TrimSpace(profile)
+		if profile != "" && !unique[profile] {
+			unique[profile] = true
+			result = append(result, profile)
+		}
+	}
+	return result
+}
+
+func filterActiveHolders(holders []*holder, profiles []string) []*holder {
+	result := make([]*holder, 0, len(holders))
+	for _, hldr := range holders {
+		if isHolderActive(hldr, profiles) {
+			result = append(result, hldr)
+		}
+	}
+	return result
+}
+
+func isHolderActive(hldr *holder, profiles []string) bool {
+	for _, group := range hldr.profiles {
+		if !matchesAnyProfile(group, profiles) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAnyProfile(group []string, profiles []string) bool {
+	for _, expected := range group {
+		negated := strings.HasPrefix(expected, "!")
+		name := strings.TrimPrefix(expected, "!")
+		active := false
+		for _, profile := range profiles {
+			if profile == name {
+				active = true
+				break
+			}
+		}
+		if active != negated {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package di
+
+import (
+	"reflect"
+)
+
+func validateReachability(ctx *Context, roots []Dependency) *Error {
+	reached := make(map[*holder]bool)
+	queue := make([]Dependency, len(roots))
+	copy(queue, roots)
+	for _, hldr := range ctx.holders() {
+		if hldr.eager {
+			reached[hldr] = true
+			queue = append(queue, hldr.deps...)
+		}
+	}
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
+		for _, hldr := range dependencyHolders(ctx, dep) {
+			if reached[hldr] {
+				continue
+			}
+			reached[hldr] = true
+			queue = append(queue, hldr.deps...)
+		}
+	}
+	unreachable := make([]string, 0)
+	for _, hldr := range ctx.holders() {
+		if !reached[hldr] {
+			unreachable = append(unreachable, ctx.holderDescriptor(hldr))
+		}
+	}
+	if len(unreachable) > 0 {
+		return newUnreachableDependencyError(unreachable)
+	}
+	return nil
+}
+
+func dependencyHolders(ctx *Context, dep Dependency) []*holder {
+	if dep.Name != "" {
+		if hldr := ctx.namedHolder(dep.Name); hldr != nil {
+			return []*holder{hldr}
+		}
+		return nil
+	}
+	if dep.Group != "" {
+		return ctx.holdersByGroup[dep.Group]
+	}
+	if ctx.hasRegisteredMap(dep) {
+		return ctx.holdersByType[dep.mapType]
+	}
+	if dep.ByName {
+		holders := make([]*holder, 0)
+		for _, hldr := range ctx.holdersByName {
+			if hldr.providesType.AssignableTo(dep.Type) {
+				holders = append(holders, hldr)
+			}
+		}
+		return holders
+	}
+	holders := ctx.holdersByType[dep.Type]
+	if len(holders) == 0 && !dep.Multiple && ctx.implicitIfaces && dep.Type.Kind() == reflect.Interface {
+		return ctx.implementingHolders(dep.Type)
+	}
+	return holders
+}
@@ -0,0 +1,83 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func AddAs[T any](ctxb *ContextBuilder, value T, opts ...Option) {
+	if err := AddAsOrErr[T](ctxb, value, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func AddAsOrErr[T any](ctxb *ContextBuilder, value T, opts ...Option) *Error {
+	return ctxb.addAsOrErr(genericTypeOf[T](), value, false, opts)
+}
+
+func AddNamedAs[T any](ctxb *ContextBuilder, name string, value T, opts ...Option) {
+	if err := AddNamedAsOrErr[T](ctxb, name, value, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func AddNamedAsOrErr[T any](ctxb *ContextBuilder, name string, value T, opts ...Option) *Error {
+	return ctxb.addNamedAsOrErr(name, genericTypeOf[T](), value, false, opts)
+}
+
+func Provide[T any](ctxb *ContextBuilder, ctor any, opts ...Option) {
+	if err := ProvideOrErr[T](ctxb, ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func ProvideOrErr[T any](ctxb *ContextBuilder, ctor any, opts ...Option) *Error {
+	if err := validateConstructorResult(ctor, genericTypeOf[T]()); err != nil {
+		return ctxb.withCallerLocation(err)
+	}
+	return ctxb.addOrErr(ctor, true, opts)
+}
+
+func ProvideNamed[T any](ctxb *ContextBuilder, name string, ctor any, opts ...Option) {
+	if err := ProvideNamedOrErr[T](ctxb, name, ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func ProvideNamedOrErr[T any](ctxb *ContextBuilder, name string, ctor any, opts ...Option) *Error {
+	if err := validateConstructorResult(ctor, genericTypeOf[T]()); err != nil {
+		return ctxb.withCallerLocation(err)
+	}
+	return ctxb.addNamedOrErr(name, ctor, true, opts)
+}
+
+func ProvideAs[T any](ctxb *ContextBuilder, ctor any, opts ...Option) {
+	if err := ProvideAsOrErr[T](ctxb, ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func ProvideAsOrErr[T any](ctxb *ContextBuilder, ctor any, opts ...Option) *Error {
+	return ctxb.addAsOrErr(genericTypeOf[T](), ctor, true, opts)
+}
+
+func ProvideNamedAs[T any](ctxb *ContextBuilder, name string, ctor any, opts ...Option) {
+	if err := ProvideNamedAsOrErr[T](ctxb, name, ctor, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func ProvideNamedAsOrErr[T any](ctxb *ContextBuilder, name string, ctor any, opts ...Option) *Error {
+	return ctxb.addNamedAsOrErr(name, genericTypeOf[T](), ctor, true, opts)
+}
+
+func validateConstructorResult(ctor any, rtype reflect.Type) *Error {
+	ctype := reflect.TypeOf(ctor)
+	if ctype == nil || ctype.Kind() != reflect.Func {
+		return newInvalidConstructorError("expected constructor function")
+	}
+	if ctype.NumOut() < 1 || ctype.Out(0) != rtype {
+		return newInvalidConstructorError(fmt.Sprintf("expected constructor result of type %s", rtype))
+	}
+	return nil
+}
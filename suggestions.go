@@ -0,0 +1,86 @@
+package di
+
+import (
+	"reflect"
+	"sort"
+)
+
+func (ctx *Context) typeSuggestions(rtype reflect.Type) []string {
+	variant := reflect.PointerTo(rtype)
+	if rtype.Kind() == reflect.Pointer {
+		variant = rtype.Elem()
+	}
+	result := make([]string, 0)
+	for _, hldr := range ctx.holders() {
+		for _, t := range ctx.holderTypes(hldr) {
+			if t == variant || t == rtype || (rtype.Kind() == reflect.Interface && t.Implements(rtype)) {
+				result = append(result, ctx.holderDescriptor(hldr))
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (ctx *Context) nameSuggestions(name string) []string {
+	maxDistance := len(name) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	distances := make(map[string]int)
+	names := make([]string, 0)
+	for n := range ctx.holdersByName {
+		if d := levenshtein(name, n); d <= maxDistance {
+			distances[n] = d
+			names = append(names, n)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if distances[names[i]] != distances[names[j]] {
+			return distances[names[i]] < distances[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	result := make([]string, len(names))
+	for i, n := range names {
+		result[i] = descriptor(&n, &ctx.holdersByName[n].providesType)
+	}
+	return result
+}
+
+func (ctx *Context) holderTypes(hldr *holder) []reflect.Type {
+	result := []reflect.Type{hldr.providesType}
+	if inst := ctx.instanceOf(hldr); inst.created && inst.value != nil {
+		if itype := reflect.TypeOf(inst.value); itype != hldr.providesType {
+			result = append(result, itype)
+		}
+	}
+	return result
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
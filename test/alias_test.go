@@ -0,0 +1,115 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type AliasSuite struct {
+	suite.Suite
+}
+
+func (suite *AliasSuite) TestGetByAlias() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("new-foo", &foo)
+	ctxb.Alias("old-foo", "new-foo")
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.GetNamed[*Foo](ctx, "old-foo"))
+	suite.Equal(&foo, di.GetNamed[*Foo](ctx, "new-foo"))
+	suite.True(di.HasNamed[*Foo](ctx, "old-foo"))
+}
+
+func (suite *AliasSuite) TestResolveAliasChain() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	ctxb.Alias("foo-v1", "foo-v2")
+	ctxb.Alias("foo-v2", "foo")
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.GetNamed[*Foo](ctx, "foo-v1"))
+	suite.Equal([]string{"foo-v1", "foo-v2"}, ctx.Registrations()[0].Aliases)
+}
+
+func (suite *AliasSuite) TestInjectByAlias() {
+	type Params struct {
+		di.In
+		Foo *Foo `name:"old-foo"`
+	}
+	type Boo struct {
+		foo *Foo
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("new-foo", &foo)
+	ctxb.Alias("old-foo", "new-foo")
+	ctxb.Provide(func(p Params) *Boo {
+		return &Boo{foo: p.Foo}
+	})
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Boo](ctx).foo)
+}
+
+func (suite *AliasSuite) TestReportDeprecatedAlias() {
+	reported := make([]string, 0)
+	ctxb := di.NewContextBuilder()
+	ctxb.OnDeprecatedAlias(func(alias string, name string) {
+		reported = append(reported, alias+" → "+name)
+	})
+	ctxb.AddNamed("new-foo", &foo)
+	ctxb.DeprecatedAlias("old-foo", "new-foo")
+	ctx := ctxb.Build()
+	di.GetNamed[*Foo](ctx, "new-foo")
+	suite.Empty(reported)
+	di.GetNamed[*Foo](ctx, "old-foo")
+	suite.Equal([]string{"old-foo → new-foo"}, reported)
+}
+
+func (suite *AliasSuite) TestErrorOnDanglingAlias() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Alias("old-foo", "new-foo")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("dangling alias: old-foo → new-foo", err.Error())
+	suite.ErrorIs(err, di.ErrMissingDependency)
+}
+
+func (suite *AliasSuite) TestSkipAliasOfInactiveName() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("new-foo", &foo, di.Profile("prod"))
+	ctxb.Alias("old-foo", "new-foo")
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+	suite.False(ctx.HasNamed("old-foo"))
+	ctxb.ActivateProfiles("prod")
+	ctx = ctxb.Build()
+	suite.Equal(&foo, di.GetNamed[*Foo](ctx, "old-foo"))
+}
+
+func (suite *AliasSuite) TestErrorOnCyclicAlias() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Alias("a", "b")
+	ctxb.Alias("b", "a")
+	_, err := ctxb.BuildOrErr()
+	suite.ErrorIs(err, di.ErrCyclicDependency)
+	suite.Contains([]string{"cyclic alias: a → b → a", "cyclic alias: b → a → b"}, err.Error())
+}
+
+func (suite *AliasSuite) TestErrorOnAliasClashingWithName() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	ctxb.AddNamed("bar", bar)
+	ctxb.Alias("foo", "bar")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("duplicated dependency name: foo", err.Error())
+}
+
+func (suite *AliasSuite) TestErrorOnDuplicatedAlias() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Alias("foo", "foo-v1")
+	err := ctxb.AliasOrErr("foo", "foo-v2")
+	suite.Equal("duplicated dependency name: foo", err.Error())
+}
+
+func TestAliasSuite(t *testing.T) {
+	suite.Run(t, new(AliasSuite))
+}
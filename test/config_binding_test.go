@@ -0,0 +1,163 @@
+package di_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type DBConfig struct {
+	Host     string        `config:"host" default:"localhost"`
+	Port     int           `config:"port" default:"5432"`
+	User     string        `config:"user" required:"true"`
+	Timeout  time.Duration `config:"timeout" default:"5s"`
+	Replicas []string      `config:"replicas"`
+	Pool     PoolConfig    `config:"pool"`
+}
+
+type PoolConfig struct {
+	Size int `config:"size" default:"10"`
+}
+
+type ValidatedConfig struct {
+	Port int `config:"port"`
+}
+
+func (c *ValidatedConfig) Validate() error {
+	if c.Port <= 0 {
+		return errors.New("port must be positive")
+	}
+	return nil
+}
+
+type ConfigBindingSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigBindingSuite) TestBindDefaults() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{
+		"database": map[string]any{"user": "admin"},
+	}))
+	di.BindConfig[DBConfig](ctxb, "database")
+	ctx := ctxb.Build()
+	cfg := di.Get[DBConfig](ctx)
+	suite.Equal("localhost", cfg.Host)
+	suite.Equal(5432, cfg.Port)
+	suite.Equal("admin", cfg.User)
+	suite.Equal(5*time.Second, cfg.Timeout)
+	suite.Equal(10, cfg.Pool.Size)
+}
+
+func (suite *ConfigBindingSuite) TestLayeredSources() {
+	dir := suite.T().TempDir()
+	jsonPath := filepath.Join(dir, "config.json")
+	yamlPath := filepath.Join(dir, "config.yaml")
+	suite.Nil(os.WriteFile(jsonPath, []byte(`{"database": {"host": "json-host", "port": 1000, "user": "json", "replicas": ["a", "b"]}}`), 0o600))
+	suite.Nil(os.WriteFile(yamlPath, []byte("database:\n  port: 2000\n  pool:\n    size: 20\n"), 0o600))
+	suite.T().Setenv("APP_DATABASE_USER", "env")
+	jsonSource, err := di.ConfigFromJSONFile(jsonPath)
+	suite.Nil(err)
+	yamlSource, err := di.ConfigFromYAMLFile(yamlPath)
+	suite.Nil(err)
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(jsonSource)
+	ctxb.AddConfigSource(yamlSource)
+	ctxb.AddConfigSource(di.ConfigFromEnv("app"))
+	ctxb.AddConfigSource(di.ConfigFromFlags([]string{"--database.timeout=1m", "--database.host", "flag-host"}))
+	di.BindConfig[DBConfig](ctxb, "database")
+	ctx := ctxb.Build()
+	cfg := di.Get[DBConfig](ctx)
+	suite.Equal("flag-host", cfg.Host)
+	suite.Equal(2000, cfg.Port)
+	suite.Equal("env", cfg.User)
+	suite.Equal(time.Minute, cfg.Timeout)
+	suite.Equal([]string{"a", "b"}, cfg.Replicas)
+	suite.Equal(20, cfg.Pool.Size)
+}
+
+func (suite *ConfigBindingSuite) TestInjectConfig() {
+	type Repository struct {
+		cfg DBConfig
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{"database.user": "admin"}))
+	di.BindConfig[DBConfig](ctxb, "database")
+	ctxb.Provide(func(cfg DBConfig) *Repository {
+		return &Repository{cfg: cfg}
+	})
+	ctx := ctxb.Build()
+	repo := di.Get[*Repository](ctx)
+	suite.Equal("admin", repo.cfg.User)
+}
+
+func (suite *ConfigBindingSuite) TestErrorOnMissingRequiredValue() {
+	ctxb := di.NewContextBuilder()
+	di.BindConfig[DBConfig](ctxb, "database")
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(ctx)
+	suite.Equal("missing config value: database.user", err.Error())
+	suite.Equal(di.ErrTypeInvalidConfig, err.ErrType())
+}
+
+func (suite *ConfigBindingSuite) TestErrorOnInvalidValue() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{
+		"database": map[string]any{"user": "admin", "port": "abc"},
+	}))
+	di.BindConfig[DBConfig](ctxb, "database")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("invalid config value: database.port, cause:\nstrconv.ParseInt: parsing \"abc\": invalid syntax", err.Error())
+	suite.Equal(di.ErrTypeInvalidConfig, err.ErrType())
+}
+
+func (suite *ConfigBindingSuite) TestTreatNullAsUnset() {
+	dir := suite.T().TempDir()
+	jsonPath := filepath.Join(dir, "config.json")
+	suite.Require().NoError(os.WriteFile(jsonPath, []byte(`{"database":{"host":null,"user":"admin"}}`), 0o600))
+	jsonSource, err := di.ConfigFromJSONFile(jsonPath)
+	suite.Require().NoError(err)
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(jsonSource)
+	di.BindConfig[DBConfig](ctxb, "database")
+	ctx := ctxb.Build()
+	suite.Equal("localhost", di.Get[DBConfig](ctx).Host)
+}
+
+func (suite *ConfigBindingSuite) TestErrorOnNullRequiredValue() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{
+		"database": map[string]any{"user": nil},
+	}))
+	di.BindConfig[DBConfig](ctxb, "database")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("missing config value: database.user", err.Error())
+}
+
+func (suite *ConfigBindingSuite) TestErrorOnNullListItem() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{
+		"database": map[string]any{"user": "admin", "replicas": []any{"a", nil}},
+	}))
+	di.BindConfig[DBConfig](ctxb, "database")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("invalid config value: database.replicas, cause:\nunexpected null value", err.Error())
+}
+
+func (suite *ConfigBindingSuite) TestErrorOnFailedValidation() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{"server.port": -1}))
+	di.BindConfig[ValidatedConfig](ctxb, "server")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("invalid config value: server, cause:\nport must be positive", err.Error())
+}
+
+func TestConfigBindingSuite(t *testing.T) {
+	suite.Run(t, new(ConfigBindingSuite))
+}
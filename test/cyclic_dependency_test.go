@@ -52,10 +52,12 @@ type CyclicDependencySuite struct {
 func (suite *CyclicDependencySuite) TestCyclicDependencyWithInjection() {
 	tests := []struct {
 		title    string
+		ctor     string
 		register func(*di.ContextBuilder)
 	}{
 		{
 			title: "param",
+			ctor:  "func(*di_test.cyclicFoo) *di_test.cyclicBaz",
 			register: func(ctxb *di.ContextBuilder) {
 				ctxb.Provide(provideCyclicFoo)
 				ctxb.Provide(provideCyclicBar)
@@ -64,6 +66,7 @@ func (suite *CyclicDependencySuite) TestCyclicDependencyWithInjection() {
 		},
 		{
 			title: "context",
+			ctor:  "func(*di.Context) *di_test.cyclicBaz",
 			register: func(ctxb *di.ContextBuilder) {
 				ctxb.Provide(provideCyclicFooWithCtx)
 				ctxb.Provide(provideCyclicBarWithCtx)
@@ -72,6 +75,7 @@ func (suite *CyclicDependencySuite) TestCyclicDependencyWithInjection() {
 		},
 		{
 			title: "mixed",
+			ctor:  "func(*di_test.cyclicFoo) *di_test.cyclicBaz",
 			register: func(ctxb *di.ContextBuilder) {
 				ctxb.Provide(provideCyclicFoo)
 				ctxb.Provide(provideCyclicBarWithCtx)
@@ -88,10 +92,9 @@ func (suite *CyclicDependencySuite) TestCyclicDependencyWithInjection() {
 			result, err := di.GetOrErr[*cyclicFoo](ctx)
 			suite.Nil(result)
 			suite.Equal(strings.Join([]string{
-				"could not create dependency *di_test.cyclicFoo, cause:",
-				"could not create dependency *di_test.cyclicBar, cause:",
-				"could not create dependency *di_test.cyclicBaz, cause:",
-				"cyclic dependency: *di_test.cyclicFoo -> *di_test.cyclicBar -> *di_test.cyclicBaz -> *di_test.cyclicFoo",
+				"could not create dependency *di_test.cyclicFoo → *di_test.cyclicBar → *di_test.cyclicBaz: " +
+					"cyclic dependency: *di_test.cyclicFoo -> *di_test.cyclicBar -> *di_test.cyclicBaz -> *di_test.cyclicFoo",
+				"failing constructor: " + tt.ctor,
 			}, "\n"),
 				err.Error())
 			suite.Equal(di.ErrTypeDependencyCreation, err.ErrType())
@@ -108,7 +111,8 @@ func (suite *CyclicDependencySuite) TestCyclicDependencyOfOne() {
 	ctx := ctxb.Build()
 	result, err := di.GetOrErr[*Foo](ctx)
 	suite.Nil(result)
-	suite.Equal("could not create dependency *di_test.Foo, cause:\ncyclic dependency: *di_test.Foo -> *di_test.Foo", err.Error())
+	suite.Equal("could not create dependency *di_test.Foo: cyclic dependency: *di_test.Foo -> *di_test.Foo\n"+
+		"failing constructor: func(*di_test.Foo) *di_test.Foo", err.Error())
 	suite.Equal(di.ErrTypeDependencyCreation, err.ErrType())
 	suite.Equal(di.ErrTypeCyclicDependency, err.RootCause().(*di.Error).ErrType())
 }
@@ -0,0 +1,92 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type EagerProviderSuite struct {
+	suite.Suite
+}
+
+func (suite *EagerProviderSuite) TestCreateOnBuild() {
+	type Boo struct {
+		foo *Foo
+	}
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.ProvideEager(func(foo *Foo) *Boo {
+		inits++
+		return &Boo{foo: foo}
+	})
+	suite.Equal(0, inits)
+	ctx := ctxb.Build()
+	suite.Equal(1, inits)
+	suite.Equal(&foo, di.Get[*Boo](ctx).foo)
+	suite.Equal(1, inits)
+}
+
+func (suite *EagerProviderSuite) TestReportUnusedAfterBuild() {
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideEager(func() *Foo {
+		return &foo
+	})
+	ctx := ctxb.Build()
+	suite.Equal(1, len(ctx.UnusedRegistrations()))
+	di.Get[*Foo](ctx)
+	suite.Empty(ctx.UnusedRegistrations())
+}
+
+func (suite *EagerProviderSuite) TestCreateOnBuildWithOption() {
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideNamed("foo", func() *Foo {
+		inits++
+		return &foo
+	}, di.Eager())
+	ctx := ctxb.Build()
+	suite.Equal(1, inits)
+	suite.True(ctx.Registrations()[0].Eager)
+	suite.True(ctx.Registrations()[0].Created)
+}
+
+func (suite *EagerProviderSuite) TestErrorOnBuild() {
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideEager(func(bar *Bar) *Foo {
+		return &foo
+	})
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(ctx)
+	suite.Equal("could not create dependency *di_test.Foo: missing dependency *di_test.Bar\n"+
+		"failing constructor: func(*di_test.Bar) *di_test.Foo", err.Error())
+	suite.ErrorIs(err, di.ErrMissingDependency)
+}
+
+func (suite *EagerProviderSuite) TestSkipInactiveProfile() {
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideEager(func() *Foo {
+		panic(errSimulated)
+	}, di.Profile("prod"))
+	_, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+}
+
+func (suite *EagerProviderSuite) TestTreatAsReachabilityRoot() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(42)
+	ctxb.Add(bar)
+	ctxb.ProvideEager(func(bar Bar) *Foo {
+		return &foo
+	})
+	ctxb.DeclareRoots(new(int))
+	_, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+}
+
+func TestEagerProviderSuite(t *testing.T) {
+	suite.Run(t, new(EagerProviderSuite))
+}
@@ -0,0 +1,60 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type GenericRegistrationSuite struct {
+	suite.Suite
+}
+
+func (suite *GenericRegistrationSuite) TestAddAs() {
+	ctxb := di.NewContextBuilder()
+	di.AddAs[Baz](ctxb, &foo)
+	di.AddAs[Baz](ctxb, bar)
+	di.AddNamedAs[Baz](ctxb, "foo2", &foo2)
+	ctx := ctxb.Build()
+	suite.Equal([]Baz{&foo, bar, &foo2}, di.GetAll[Baz](ctx))
+	suite.Equal(&foo2, di.GetNamed[Baz](ctx, "foo2"))
+	suite.False(di.Has[*Foo](ctx))
+}
+
+func (suite *GenericRegistrationSuite) TestProvide() {
+	ctxb := di.NewContextBuilder()
+	di.Provide[*Foo](ctxb, func() *Foo { return &foo })
+	di.ProvideNamed[*Bar](ctxb, "bar", func() (*Bar, error) { return &bar, nil })
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	suite.Equal(&bar, di.GetNamed[*Bar](ctx, "bar"))
+}
+
+func (suite *GenericRegistrationSuite) TestProvideAs() {
+	ctxb := di.NewContextBuilder()
+	di.ProvideAs[Baz](ctxb, func() *Foo { return &foo })
+	di.ProvideNamedAs[Baz](ctxb, "bar", func() *Bar { return &bar })
+	ctx := ctxb.Build()
+	suite.Equal([]Baz{&foo, &bar}, di.GetAll[Baz](ctx))
+	suite.Equal(&bar, di.GetNamed[Baz](ctx, "bar"))
+}
+
+func (suite *GenericRegistrationSuite) TestErrorOnInvalidConstructorResult() {
+	ctxb := di.NewContextBuilder()
+	err := di.ProvideOrErr[Baz](ctxb, func() *Foo { return &foo })
+	suite.Equal("invalid dependency constructor: expected constructor result of type di_test.Baz", err.Error())
+	suite.Equal(di.ErrTypeInvalidConstructor, err.ErrType())
+}
+
+func (suite *GenericRegistrationSuite) TestErrorOnNotAssignableConstructorResult() {
+	ctxb := di.NewContextBuilder()
+	err := di.ProvideAsOrErr[*Bar](ctxb, func() *Foo { return &foo })
+	suite.Equal("could not cast *di_test.Foo to *di_test.Bar", err.Error())
+	suite.Equal(di.ErrTypeInvalidType, err.ErrType())
+}
+
+func TestGenericRegistrationSuite(t *testing.T) {
+	suite.Run(t, new(GenericRegistrationSuite))
+}
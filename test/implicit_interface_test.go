@@ -0,0 +1,81 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type ImplicitInterfaceSuite struct {
+	suite.Suite
+}
+
+func (suite *ImplicitInterfaceSuite) TestResolveUniqueImplementation() {
+	type Boo struct {
+		baz Baz
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableImplicitInterfaces()
+	ctxb.Add(&foo)
+	ctxb.Add(42)
+	ctxb.Provide(func(baz Baz) *Boo {
+		return &Boo{baz: baz}
+	})
+	ctx := ctxb.Build()
+	suite.True(di.Has[Baz](ctx))
+	suite.Equal(&foo, di.Get[Baz](ctx))
+	suite.Equal(&foo, di.Get[*Boo](ctx).baz)
+}
+
+func (suite *ImplicitInterfaceSuite) TestReachUniqueImplementationFromRoots() {
+	type Boo struct {
+		baz Baz
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableImplicitInterfaces()
+	ctxb.Add(&foo)
+	ctxb.Provide(func(baz Baz) *Boo {
+		return &Boo{baz: baz}
+	})
+	ctxb.DeclareRoots(new(*Boo))
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+	suite.Equal(&foo, di.Get[*Boo](ctx).baz)
+}
+
+func (suite *ImplicitInterfaceSuite) TestPreferExplicitRegistration() {
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableImplicitInterfaces()
+	ctxb.Add(&foo)
+	ctxb.AddAs(new(Baz), &bar)
+	ctx := ctxb.Build()
+	suite.Equal(&bar, di.Get[Baz](ctx))
+}
+
+func (suite *ImplicitInterfaceSuite) TestErrorOnAmbiguousImplementations() {
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableImplicitInterfaces()
+	ctxb.Add(&foo)
+	ctxb.AddNamed("bar", bar)
+	ctx := ctxb.Build()
+	suite.False(di.Has[Baz](ctx))
+	result, err := di.GetOrErr[Baz](ctx)
+	suite.Nil(result)
+	suite.Equal("ambiguous dependency di_test.Baz, candidates: *di_test.Foo, di_test.Bar (name: bar)", err.Error())
+	suite.Equal(di.ErrTypeAmbiguousDependency, err.ErrType())
+}
+
+func (suite *ImplicitInterfaceSuite) TestDisabledByDefault() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctx := ctxb.Build()
+	suite.False(di.Has[Baz](ctx))
+	_, err := di.GetOrErr[Baz](ctx)
+	suite.Equal("missing dependency di_test.Baz, did you mean: *di_test.Foo", err.Error())
+}
+
+func TestImplicitInterfaceSuite(t *testing.T) {
+	suite.Run(t, new(ImplicitInterfaceSuite))
+}
@@ -0,0 +1,116 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type Qux interface {
+	Qux() string
+}
+
+type FooQux struct {
+	Foo
+}
+
+func (f *FooQux) Qux() string {
+	return "qux-" + f.id
+}
+
+type InterfaceRegistrationSuite struct {
+	suite.Suite
+}
+
+func (suite *InterfaceRegistrationSuite) TestAddWithInterfaces() {
+	fooQux := FooQux{Foo{id: "foo-qux"}}
+	ctxb := di.NewContextBuilder()
+	ctxb.AddAs(new(Baz), &foo)
+	ctxb.AddWithInterfaces(&fooQux, new(Baz), new(Qux))
+	ctx := ctxb.Build()
+	suite.Equal(&fooQux, di.Get[*FooQux](ctx))
+	suite.Equal(&fooQux, di.Get[Qux](ctx))
+	suite.Equal([]Baz{&foo, &fooQux}, di.GetAll[Baz](ctx))
+}
+
+func (suite *InterfaceRegistrationSuite) TestProvideWithInterfacesCreatesOnce() {
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideWithInterfaces(func() *FooQux {
+		inits++
+		return &FooQux{Foo{id: "foo-qux"}}
+	}, new(Baz), new(Qux))
+	ctx := ctxb.Build()
+	fooQux := di.Get[*FooQux](ctx)
+	suite.Equal(fooQux, di.Get[Baz](ctx))
+	suite.Equal(fooQux, di.Get[Qux](ctx))
+	suite.Equal(1, inits)
+}
+
+func (suite *InterfaceRegistrationSuite) TestRegisterWithInterfacesAndOptions() {
+	fooQux := FooQux{Foo{id: "foo-qux"}}
+	ctxb := di.NewContextBuilder()
+	ctxb.ActivateProfiles("test")
+	ctxb.AddWithInterfaces(&fooQux, new(Qux), di.Profile("prod"))
+	ctxb.ProvideWithInterfaces(func() *Foo { return &foo }, new(Baz), di.Profile("test"))
+	ctx := ctxb.Build()
+	suite.False(di.Has[Qux](ctx))
+	suite.Equal(&foo, di.Get[Baz](ctx))
+}
+
+func (suite *InterfaceRegistrationSuite) TestExposeInterfaces() {
+	fooQux := FooQux{Foo{id: "foo-qux"}}
+	ctxb := di.NewContextBuilder()
+	ctxb.ExposeInterfaces(new(Baz), new(Qux))
+	ctxb.Add(&foo)
+	ctxb.AddAs(new(Baz), &bar)
+	ctxb.AddNamed("foo-qux", &fooQux)
+	ctx := ctxb.Build()
+	suite.Equal([]Baz{&foo, &bar, &fooQux}, di.GetAll[Baz](ctx))
+	suite.Equal([]Qux{&fooQux}, di.GetAll[Qux](ctx))
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+}
+
+func (suite *InterfaceRegistrationSuite) TestExposeInterfacesForAllRegistrationVariants() {
+	typed := FooQux{Foo{id: "typed"}}
+	namedTyped := FooQux{Foo{id: "named-typed"}}
+	keyed := FooQux{Foo{id: "keyed"}}
+	ctxb := di.NewContextBuilder()
+	ctxb.ExposeInterfaces(new(Qux))
+	ctxb.AddAs(new(Baz), &typed)
+	ctxb.AddNamedAs("named-typed", new(Baz), &namedTyped)
+	di.AddKeyed(ctxb, di.NewKey[Baz]("keyed"), Baz(&keyed))
+	ctx := ctxb.Build()
+	suite.True(di.Has[Qux](ctx))
+	suite.Equal([]Qux{&typed, &namedTyped, &keyed}, di.GetAll[Qux](ctx))
+}
+
+func (suite *InterfaceRegistrationSuite) TestExposeInterfacesForEarlierRegistrations() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.ExposeInterfaces(new(Baz))
+	ctxb.Add(&bar)
+	ctx := ctxb.Build()
+	suite.True(di.Has[Baz](ctx))
+	suite.Equal([]Baz{&foo, &bar}, di.GetAll[Baz](ctx))
+}
+
+func (suite *InterfaceRegistrationSuite) TestErrorOnNotImplementedInterface() {
+	ctxb := di.NewContextBuilder()
+	err := ctxb.AddWithInterfacesOrErr(&foo, new(Qux))
+	suite.Equal("could not cast *di_test.Foo to di_test.Qux", err.Error())
+	suite.Equal(di.ErrTypeInvalidType, err.ErrType())
+}
+
+func (suite *InterfaceRegistrationSuite) TestErrorOnNonInterfaceType() {
+	ctxb := di.NewContextBuilder()
+	err := ctxb.ExposeInterfacesOrErr(new(*Foo))
+	suite.Equal("expected interface type, got *di_test.Foo", err.Error())
+	suite.Equal(di.ErrTypeInvalidType, err.ErrType())
+}
+
+func TestInterfaceRegistrationSuite(t *testing.T) {
+	suite.Run(t, new(InterfaceRegistrationSuite))
+}
@@ -0,0 +1,84 @@
+package di_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type IntrospectionSuite struct {
+	suite.Suite
+}
+
+func (suite *IntrospectionSuite) TestListRegistrations() {
+	type Boo struct {
+		foo *Foo
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.AddNamedAs("bar", new(Baz), &bar)
+	ctxb.Provide(func(foo *Foo) *Boo {
+		return &Boo{foo: foo}
+	})
+	ctx := ctxb.Build()
+	regs := ctx.Registrations()
+	suite.Equal(3, len(regs))
+
+	suite.Equal(reflect.TypeOf(&foo), regs[0].Type)
+	suite.Equal([]reflect.Type{reflect.TypeOf(&foo)}, regs[0].Types)
+	suite.Nil(regs[0].Names)
+	suite.False(regs[0].Lazy)
+	suite.True(regs[0].Created)
+
+	suite.Equal(reflect.TypeOf(&bar), regs[1].Type)
+	suite.Equal([]reflect.Type{reflect.TypeOf(new(Baz)).Elem()}, regs[1].Types)
+	suite.Equal([]string{"bar"}, regs[1].Names)
+
+	suite.Equal(reflect.TypeOf(&Boo{}), regs[2].Type)
+	suite.True(regs[2].Lazy)
+	suite.False(regs[2].Created)
+	suite.Equal([]reflect.Type{reflect.TypeOf(&foo)}, regs[2].Params)
+}
+
+func (suite *IntrospectionSuite) TestRegistrationCreationState() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *Foo { return &foo })
+	ctx := ctxb.Build()
+	suite.False(ctx.Registrations()[0].Created)
+	di.Get[*Foo](ctx)
+	suite.True(ctx.Registrations()[0].Created)
+}
+
+func (suite *IntrospectionSuite) TestRegistrationLifecycleInterfaces() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&CtxAwareFoo{})
+	ctxb.Add(&foo)
+	ctx := ctxb.Build()
+	regs := ctx.Registrations()
+	suite.True(regs[0].Initializable)
+	suite.True(regs[0].Shutdownable)
+	suite.False(regs[1].Initializable)
+	suite.False(regs[1].Shutdownable)
+}
+
+func (suite *IntrospectionSuite) TestHasDoesNotCreateDependency() {
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideNamed("foo", func() *Foo { inits++; return &foo })
+	ctx := ctxb.Build()
+	suite.True(di.Has[*Foo](ctx))
+	suite.True(ctx.HasType(new(*Foo)))
+	suite.True(ctx.HasNamed("foo"))
+	suite.True(di.HasNamed[*Foo](ctx, "foo"))
+	suite.False(di.HasNamed[*Bar](ctx, "foo"))
+	suite.False(di.Has[*Bar](ctx))
+	suite.False(ctx.HasNamed("bar"))
+	suite.Equal(0, inits)
+}
+
+func TestIntrospectionSuite(t *testing.T) {
+	suite.Run(t, new(IntrospectionSuite))
+}
@@ -0,0 +1,91 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type Router struct {
+	routes []string
+}
+
+type InvokeSuite struct {
+	suite.Suite
+}
+
+func (suite *InvokeSuite) TestInvokeOnBuild() {
+	router := Router{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&router)
+	ctxb.AddAs(new(Baz), &foo)
+	ctxb.AddAs(new(Baz), &bar)
+	ctxb.Invoke(func(r *Router, bazes []Baz) error {
+		for _, baz := range bazes {
+			r.routes = append(r.routes, baz.Id())
+		}
+		return nil
+	})
+	ctxb.Invoke(func(r *Router) {
+		r.routes = append(r.routes, "last")
+	})
+	suite.Equal(0, len(router.routes))
+	ctxb.Build()
+	suite.Equal([]string{"foo", "bar", "last"}, router.routes)
+}
+
+func (suite *InvokeSuite) TestInvokeOnInit() {
+	router := Router{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&router)
+	ctxb.InvokeOnInit(func(r *Router) {
+		r.routes = append(r.routes, "init")
+	})
+	ctx := ctxb.Build()
+	suite.Equal(0, len(router.routes))
+	ctx.Initialize()
+	suite.Equal([]string{"init"}, router.routes)
+}
+
+func (suite *InvokeSuite) TestErrorOnInvocation() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Invoke(func() error {
+		return errSimulated
+	})
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(ctx)
+	suite.Equal("could not invoke function func() error, cause:\nsimulated", err.Error())
+	suite.Equal(di.ErrTypeInvocation, err.ErrType())
+	suite.ErrorIs(err, errSimulated)
+}
+
+func (suite *InvokeSuite) TestErrorOnInvocationPanic() {
+	ctxb := di.NewContextBuilder()
+	ctxb.InvokeOnInit(func(foo *Foo) {
+		panic(errSimulated)
+	})
+	ctxb.Add(&foo)
+	ctx := ctxb.Build()
+	err := ctx.InitializeOrErr()
+	suite.Equal("could not invoke function func(*di_test.Foo), cause:\nsimulated", err.Error())
+	suite.ErrorIs(err, errSimulated)
+}
+
+func (suite *InvokeSuite) TestErrorOnMissingDependency() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Invoke(func(foo *Foo) {})
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("could not invoke function func(*di_test.Foo), cause:\nmissing dependency *di_test.Foo", err.Error())
+}
+
+func (suite *InvokeSuite) TestErrorOnInvalidFunction() {
+	ctxb := di.NewContextBuilder()
+	err := ctxb.InvokeOrErr(func() *Foo { return &foo })
+	suite.Equal("invalid invocation: expected no result value or a single error", err.Error())
+}
+
+func TestInvokeSuite(t *testing.T) {
+	suite.Run(t, new(InvokeSuite))
+}
@@ -0,0 +1,75 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+var (
+	primaryFoo = di.NewKey[*Foo]("primary")
+	primaryBaz = di.NewKey[Baz]("primary")
+)
+
+type KeyedDependencySuite struct {
+	suite.Suite
+}
+
+func (suite *KeyedDependencySuite) TestGetKeyed() {
+	ctxb := di.NewContextBuilder()
+	di.AddKeyed(ctxb, primaryFoo, &foo)
+	ctxb.Add(&foo2)
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.GetKeyed(ctx, primaryFoo))
+	suite.True(di.HasKeyed(ctx, primaryFoo))
+	suite.False(di.HasKeyed(ctx, primaryBaz))
+}
+
+func (suite *KeyedDependencySuite) TestProvideKeyed() {
+	ctxb := di.NewContextBuilder()
+	di.ProvideKeyed(ctxb, primaryBaz, func(foo *Foo) *Foo {
+		return foo
+	})
+	ctxb.Add(&foo)
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.GetKeyed(ctx, primaryBaz))
+}
+
+func (suite *KeyedDependencySuite) TestReuseNameForDifferentTypes() {
+	ctxb := di.NewContextBuilder()
+	di.AddKeyed(ctxb, primaryFoo, &foo)
+	di.AddKeyed[Baz](ctxb, primaryBaz, bar)
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.GetKeyed(ctx, primaryFoo))
+	suite.Equal(bar, di.GetKeyed(ctx, primaryBaz))
+}
+
+func (suite *KeyedDependencySuite) TestErrorOnDuplicatedKey() {
+	ctxb := di.NewContextBuilder()
+	di.AddKeyed(ctxb, primaryFoo, &foo)
+	err := di.AddKeyedOrErr(ctxb, primaryFoo, &foo2)
+	suite.Equal("duplicated dependency key: *di_test.Foo (key: primary)", err.Error())
+	suite.Equal(di.ErrTypeDuplicatedName, err.ErrType())
+}
+
+func (suite *KeyedDependencySuite) TestErrorOnMissingKey() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctx := ctxb.Build()
+	_, err := di.GetKeyedOrErr(ctx, primaryFoo)
+	suite.Equal("missing dependency *di_test.Foo (name: primary)", err.Error())
+	suite.ErrorIs(err, di.ErrMissingDependency)
+}
+
+func (suite *KeyedDependencySuite) TestExposeKeys() {
+	ctxb := di.NewContextBuilder()
+	di.AddKeyed(ctxb, primaryFoo, &foo)
+	ctx := ctxb.Build()
+	suite.Equal([]string{"primary"}, ctx.Registrations()[0].Keys)
+}
+
+func TestKeyedDependencySuite(t *testing.T) {
+	suite.Run(t, new(KeyedDependencySuite))
+}
@@ -1,6 +1,7 @@
 package di_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -20,7 +21,8 @@ func (suite *LazyDependencyErrorSuite) TestErrorResult() {
 	ctx := ctxb.Build()
 	result, err := di.GetOrErr[*Foo](ctx)
 	suite.Nil(result)
-	suite.Equal("could not create dependency *di_test.Foo, cause:\nsimulated", err.Error())
+	suite.Equal("could not create dependency *di_test.Foo: simulated\n"+
+		"failing constructor: func() (*di_test.Foo, error)", err.Error())
 	suite.Equal(di.ErrTypeDependencyCreation, err.ErrType())
 	suite.Equal(errSimulated, err.RootCause())
 	suite.ErrorIs(err, errSimulated)
@@ -39,6 +41,50 @@ func (suite *LazyDependencyErrorSuite) TestPanic() {
 	suite.ErrorIs(err, errSimulated)
 }
 
+func (suite *LazyDependencyErrorSuite) TestPanicWithNonErrorValue() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *Foo {
+		panic(42)
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	var perr *di.PanicError
+	suite.ErrorAs(err, &perr)
+	suite.Equal(42, perr.Value)
+	suite.Equal("could not create dependency *di_test.Foo: panic: 42\n"+
+		"failing constructor: func() *di_test.Foo", err.Error())
+}
+
+func (suite *LazyDependencyErrorSuite) TestPanicStackTrace() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *Foo {
+		panic(errSimulated)
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	suite.NotContains(fmt.Sprintf("%v", err), "panic stack:")
+	suite.Contains(fmt.Sprintf("%+v", err), "panic stack:")
+	suite.Contains(fmt.Sprintf("%+v", err), "lazy_dependency_error_test.go")
+}
+
+func (suite *LazyDependencyErrorSuite) TestDisablePanicRecovery() {
+	ctxb := di.NewContextBuilder()
+	ctxb.DisablePanicRecovery()
+	ctxb.Provide(func() *Foo {
+		panic(errSimulated)
+	})
+	ctxb.Provide(func() *Bar {
+		panic(di.ErrSkippedDependency)
+	})
+	ctx := ctxb.Build()
+	suite.PanicsWithValue(errSimulated, func() {
+		di.GetOrErr[*Foo](ctx)
+	})
+	suite.NotPanics(func() {
+		di.GetOrErr[*Bar](ctx)
+	})
+}
+
 func (suite *LazyDependencyErrorSuite) TestErrorOnSliceDependency() {
 	type Boo struct {
 		baz []Baz
@@ -54,11 +100,32 @@ func (suite *LazyDependencyErrorSuite) TestErrorOnSliceDependency() {
 	ctx := ctxb.Build()
 	result, err := di.GetOrErr[*Boo](ctx)
 	suite.Nil(result)
-	suite.Equal("could not create dependency *di_test.Boo, cause:\ncould not create dependency di_test.Baz, cause:\nsimulated", err.Error())
+	suite.Equal("could not create dependency *di_test.Boo → di_test.Baz: simulated\n"+
+		"failing constructor: func() *di_test.Foo", err.Error())
 	suite.Equal(di.ErrTypeDependencyCreation, err.ErrType())
 	suite.ErrorIs(err, errSimulated)
 }
 
+func (suite *LazyDependencyErrorSuite) TestErrorWithResolutionPath() {
+	type Boo struct{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func(foo *Foo) *Boo {
+		return &Boo{}
+	})
+	ctxb.Provide(func(bar Bar) *Foo {
+		return &foo
+	})
+	ctxb.Provide(func() (Bar, error) {
+		return Bar{}, errSimulated
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Boo](ctx)
+	suite.Equal("could not create dependency *di_test.Boo → *di_test.Foo → di_test.Bar: simulated\n"+
+		"failing constructor: func() (di_test.Bar, error)", err.Error())
+	suite.Equal([]string{"*di_test.Boo", "*di_test.Foo", "di_test.Bar"}, err.Path())
+	suite.Equal(errSimulated, err.RootCause())
+}
+
 func TestLazyDependencyErrorSuite(t *testing.T) {
 	suite.Run(t, new(LazyDependencyErrorSuite))
 }
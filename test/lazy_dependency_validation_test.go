@@ -19,22 +19,22 @@ func (suite *LazyDependencyValidationSuite) TestErrorResult() {
 		error string
 	}{
 		{
-			title: "two results",
-			error: "invalid dependency constructor: expected second result value to be an error",
-			ctor: func() (*Foo, *Bar) {
+			title: "error not last",
+			error: "invalid dependency constructor: expected error to be the last result value",
+			ctor: func() (error, *Foo) {
 				return nil, nil
 			},
 		},
 		{
-			title: "three results, last err",
-			error: "invalid dependency constructor: expected one result value with an optional error",
-			ctor: func() (*Foo, *Bar, error) {
-				return nil, nil, nil
+			title: "only error result",
+			error: "invalid dependency constructor: expected at least one result value with an optional error",
+			ctor: func() error {
+				return nil
 			},
 		},
 		{
 			title: "zero results",
-			error: "invalid dependency constructor: expected one result value with an optional error",
+			error: "invalid dependency constructor: expected at least one result value with an optional error",
 			ctor: func() {
 			},
 		},
@@ -0,0 +1,82 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type LazyInitializationSuite struct {
+	suite.Suite
+}
+
+func (suite *LazyInitializationSuite) TestInitializeOnlyCreated() {
+	created := CtxAwareFoo{}
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableLazyInitialization()
+	ctxb.Add(&created)
+	ctxb.ProvideNamed("lazy", func() *CtxAwareFoo {
+		inits++
+		return &CtxAwareFoo{}
+	})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	suite.Equal(1, created.initialized)
+	suite.Equal(0, inits)
+}
+
+func (suite *LazyInitializationSuite) TestInitializeOnCreationAfterInitialize() {
+	type Boo struct {
+		foo *CtxAwareFoo
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableLazyInitialization()
+	ctxb.Provide(func() *CtxAwareFoo {
+		return &CtxAwareFoo{}
+	})
+	ctxb.Provide(func(foo *CtxAwareFoo) *Boo {
+		suite.Equal(1, foo.initialized)
+		return &Boo{foo: foo}
+	})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	foo := di.Get[*Boo](ctx).foo
+	suite.Equal(1, foo.initialized)
+	di.Get[*CtxAwareFoo](ctx)
+	suite.Equal(1, foo.initialized)
+}
+
+func (suite *LazyInitializationSuite) TestSkipInitializationBeforeInitialize() {
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableLazyInitialization()
+	ctxb.Provide(func() *CtxAwareFoo {
+		return &CtxAwareFoo{}
+	})
+	ctx := ctxb.Build()
+	foo := di.Get[*CtxAwareFoo](ctx)
+	suite.Equal(0, foo.initialized)
+	ctx.Initialize()
+	suite.Equal(1, foo.initialized)
+}
+
+func (suite *LazyInitializationSuite) TestErrorOnInitializationAfterCreation() {
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableLazyInitialization()
+	ctxb.Provide(func() *CtxAwareFoo {
+		return &CtxAwareFoo{errOnInitialize: true}
+	})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	_, err := di.GetOrErr[*CtxAwareFoo](ctx)
+	suite.ErrorIs(err, di.ErrDependencyInitialization)
+	suite.ErrorIs(err, errSimulated)
+	_, err = di.GetOrErr[*CtxAwareFoo](ctx)
+	suite.ErrorIs(err, di.ErrDependencyInitialization)
+}
+
+func TestLazyInitializationSuite(t *testing.T) {
+	suite.Run(t, new(LazyInitializationSuite))
+}
@@ -0,0 +1,85 @@
+package di_test
+
+import (
+	stdcontext "context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type PhasedFoo struct {
+	id     string
+	phase  int
+	events *[]string
+}
+
+func (f *PhasedFoo) Initialize() {
+	*f.events = append(*f.events, "init-"+f.id)
+}
+
+func (f *PhasedFoo) Shutdown(context stdcontext.Context) {
+	*f.events = append(*f.events, "shutdown-"+f.id)
+}
+
+func (f *PhasedFoo) Phase() int {
+	return f.phase
+}
+
+type LifecyclePhaseSuite struct {
+	suite.Suite
+}
+
+func (suite *LifecyclePhaseSuite) TestRunPhasesInOrder() {
+	events := make([]string, 0)
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&PhasedFoo{id: "consumer", phase: 10, events: &events})
+	ctxb.Add(&PhasedFoo{id: "default", events: &events})
+	ctxb.Add(&PhasedFoo{id: "migration", phase: -10, events: &events})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	ctx.Shutdown(stdcontext.Background())
+	suite.Equal([]string{
+		"init-migration", "init-default", "init-consumer",
+		"shutdown-consumer", "shutdown-default", "shutdown-migration",
+	}, events)
+}
+
+func (suite *LifecyclePhaseSuite) TestPreferPhaseOption() {
+	events := make([]string, 0)
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&PhasedFoo{id: "a", phase: 1, events: &events})
+	ctxb.Provide(func() *CtxAwareFoo {
+		events = append(events, "create-b")
+		return &CtxAwareFoo{}
+	})
+	ctxb.AddNamed("c", &PhasedFoo{id: "c", phase: 1, events: &events}, di.Phase(-1))
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	suite.Equal([]string{"create-b", "init-c", "init-a"}, events)
+}
+
+func (suite *LifecyclePhaseSuite) TestKeepRegistrationOrderWithinPhase() {
+	events := make([]string, 0)
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&PhasedFoo{id: "a", events: &events})
+	ctxb.Add(&PhasedFoo{id: "b", events: &events})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	suite.Equal([]string{"init-a", "init-b"}, events)
+}
+
+func (suite *LifecyclePhaseSuite) TestExposePhase() {
+	events := make([]string, 0)
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&PhasedFoo{id: "a", phase: 3, events: &events})
+	ctxb.Add(&foo, di.Phase(5))
+	ctx := ctxb.Build()
+	suite.Equal(3, ctx.Registrations()[0].Phase)
+	suite.Equal(5, ctx.Registrations()[1].Phase)
+}
+
+func TestLifecyclePhaseSuite(t *testing.T) {
+	suite.Run(t, new(LifecyclePhaseSuite))
+}
@@ -0,0 +1,88 @@
+package di_test
+
+import (
+	stdcontext "context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type Service interface {
+	Run()
+}
+
+func (f *CtxAwareFoo) Run() {}
+
+type LifecycleRegistrationSuite struct {
+	suite.Suite
+}
+
+func (suite *LifecycleRegistrationSuite) TestLifecycleForAllRegistrationVariants() {
+	named := CtxAwareFoo{}
+	typed := CtxAwareFoo{}
+	namedTyped := CtxAwareFoo{}
+	keyed := CtxAwareFoo{}
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("named", &named)
+	ctxb.AddAs(new(Service), &typed)
+	ctxb.AddNamedAs("named-typed", new(Service), &namedTyped)
+	di.AddKeyed(ctxb, di.NewKey[Service]("keyed"), Service(&keyed))
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	ctx.Shutdown(stdcontext.Background())
+	for _, foo := range []*CtxAwareFoo{&named, &typed, &namedTyped, &keyed} {
+		suite.Equal(1, foo.initialized)
+		suite.Equal(1, foo.shutdown)
+	}
+}
+
+func (suite *LifecycleRegistrationSuite) TestLifecycleForCreatedInterfaceProvider() {
+	foo := CtxAwareFoo{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() Service {
+		return &foo
+	})
+	ctx := ctxb.Build()
+	di.Get[Service](ctx)
+	ctx.Initialize()
+	ctx.Shutdown(stdcontext.Background())
+	suite.Equal(1, foo.initialized)
+	suite.Equal(1, foo.shutdown)
+	suite.True(ctx.Registrations()[0].Initializable)
+}
+
+func (suite *LifecycleRegistrationSuite) TestInitializeInterfaceProviderCreatedAfterInitialize() {
+	foo := CtxAwareFoo{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() Service {
+		return &foo
+	})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	suite.Equal(0, foo.initialized)
+	di.Get[Service](ctx)
+	suite.Equal(1, foo.initialized)
+	ctx.Shutdown(stdcontext.Background())
+	suite.Equal(1, foo.shutdown)
+}
+
+func (suite *LifecycleRegistrationSuite) TestInitializeInstanceRegisteredTwiceOnce() {
+	foo := CtxAwareFoo{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.Provide(func() Service {
+		return &foo
+	})
+	ctx := ctxb.Build()
+	di.Get[Service](ctx)
+	ctx.Initialize()
+	ctx.Shutdown(stdcontext.Background())
+	suite.Equal(1, foo.initialized)
+	suite.Equal(1, foo.shutdown)
+}
+
+func TestLifecycleRegistrationSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleRegistrationSuite))
+}
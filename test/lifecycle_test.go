@@ -74,6 +74,21 @@ func (suite *LifecycleSuite) TestDependencyInitError() {
 	suite.Equal(err.ErrType(), di.ErrTypeDependencyInitialization)
 }
 
+func (suite *LifecycleSuite) TestRetryInitializationAfterError() {
+	foo1 := CtxAwareFoo{}
+	foo2 := CtxAwareFoo{errOnInitialize: true}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo1)
+	ctxb.Add(&foo2)
+	ctx := ctxb.Build()
+	suite.NotNil(ctx.InitializeOrErr())
+	suite.NotNil(ctx.InitializeOrErr())
+	foo2.errOnInitialize = false
+	suite.Nil(ctx.InitializeOrErr())
+	suite.Equal(1, foo1.initialized)
+	suite.Equal(1, foo2.initialized)
+}
+
 func (suite *LifecycleSuite) TestDependencyShutdown() {
 	foo1 := CtxAwareFoo{}
 	foo2 := CtxAwareFoo{}
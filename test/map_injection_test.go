@@ -0,0 +1,97 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type MapInjectionSuite struct {
+	suite.Suite
+}
+
+func (suite *MapInjectionSuite) TestGetAllNamed() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamedAs("foo", new(Baz), &foo)
+	ctxb.AddNamed("bar", &bar)
+	ctxb.AddNamed("number", 42)
+	ctxb.Add(&foo2)
+	ctx := ctxb.Build()
+	suite.Equal(map[string]Baz{"foo": &foo, "bar": &bar}, di.GetAllNamed[Baz](ctx))
+	suite.Equal(map[string]*Foo{"foo": &foo}, di.GetAllNamed[*Foo](ctx))
+	suite.Equal(map[string]*Qux{}, di.GetAllNamed[*Qux](ctx))
+}
+
+func (suite *MapInjectionSuite) TestInjectMapOfNamedDependencies() {
+	type Registry struct {
+		drivers map[string]Baz
+	}
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideNamedAs("foo", new(Baz), func() *Foo {
+		inits++
+		return &foo
+	})
+	ctxb.AddNamed("bar", &bar)
+	ctxb.ProvideNamed("skipped", func() (*Foo, error) {
+		return nil, di.ErrSkippedDependency
+	})
+	ctxb.Provide(func(drivers map[string]Baz) *Registry {
+		return &Registry{drivers: drivers}
+	})
+	ctx := ctxb.Build()
+	result := di.Get[*Registry](ctx)
+	suite.Equal(map[string]Baz{"foo": &foo, "bar": &bar}, result.drivers)
+	suite.Equal(1, inits)
+}
+
+func (suite *MapInjectionSuite) TestErrorOnNamedDependencyCreation() {
+	type Registry struct{}
+	ctxb := di.NewContextBuilder()
+	ctxb.ProvideNamed("foo", func() (*Foo, error) {
+		return nil, errSimulated
+	})
+	ctxb.Provide(func(drivers map[string]*Foo) *Registry {
+		return &Registry{}
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Registry](ctx)
+	suite.ErrorIs(err, errSimulated)
+	suite.Equal(di.ErrTypeDependencyCreation, err.ErrType())
+}
+
+func (suite *MapInjectionSuite) TestInjectMapRegisteredByType() {
+	type Registry struct {
+		labels map[string]string
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(map[string]string{"a": "b"})
+	ctxb.AddNamed("ignored", "value")
+	ctxb.Provide(func(labels map[string]string) *Registry {
+		return &Registry{labels: labels}
+	})
+	ctx := ctxb.Build()
+	result := di.Get[*Registry](ctx)
+	suite.Equal(map[string]string{"a": "b"}, result.labels)
+}
+
+func (suite *MapInjectionSuite) TestReachMapRegisteredByTypeFromRoots() {
+	type Registry struct {
+		limits map[string]int
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(map[string]int{"a": 1})
+	ctxb.Provide(func(limits map[string]int) *Registry {
+		return &Registry{limits: limits}
+	})
+	ctxb.DeclareRoots(new(*Registry))
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+	suite.Equal(map[string]int{"a": 1}, di.Get[*Registry](ctx).limits)
+}
+
+func TestMapInjectionSuite(t *testing.T) {
+	suite.Run(t, new(MapInjectionSuite))
+}
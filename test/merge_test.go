@@ -0,0 +1,155 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type MergeSuite struct {
+	suite.Suite
+}
+
+func (suite *MergeSuite) TestMergeRegistrations() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.AddAs(new(Baz), &foo)
+	other := di.NewContextBuilder()
+	other.AddNamed("bar", bar)
+	other.AddAs(new(Baz), &bar)
+	other.Alias("old-bar", "bar")
+	ctxb.Merge(other, di.ConflictFail)
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	suite.Equal(bar, di.GetNamed[Bar](ctx, "old-bar"))
+	suite.Equal([]Baz{&foo, &bar}, di.GetAll[Baz](ctx))
+}
+
+func (suite *MergeSuite) TestMergeSameConstructorOnce() {
+	inits := 0
+	ctor := func() *Foo {
+		inits++
+		return &foo
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(ctor)
+	other := di.NewContextBuilder()
+	other.Provide(ctor)
+	ctxb.Merge(other, di.ConflictFail)
+	ctx := ctxb.Build()
+	suite.Equal([]*Foo{&foo}, di.GetAll[*Foo](ctx))
+	suite.Equal(1, inits)
+}
+
+func (suite *MergeSuite) TestMergeConstructorDeduplication() {
+	ctor := func() *Foo { return &foo }
+	ctxb := di.NewContextBuilder()
+	other := di.NewContextBuilder()
+	other.Provide(ctor)
+	ctxb.Merge(other, di.ConflictFail)
+	err := ctxb.ProvideOrErr(ctor)
+	suite.Equal("duplicated registration", err.Error())
+}
+
+func (suite *MergeSuite) TestErrorOnConflictingName() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	other := di.NewContextBuilder()
+	other.AddNamed("foo", &foo2)
+	err := ctxb.MergeOrErr(other, di.ConflictFail)
+	suite.Equal("duplicated dependency name: foo", err.Error())
+	ctx := ctxb.Build()
+	suite.Equal([]*Foo{&foo}, di.GetAll[*Foo](ctx))
+}
+
+func (suite *MergeSuite) TestKeepFirstOnConflictingName() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	other := di.NewContextBuilder()
+	other.AddNamed("foo", &foo2)
+	other.Add(bar)
+	ctxb.Merge(other, di.ConflictKeepFirst)
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.GetNamed[*Foo](ctx, "foo"))
+	suite.Equal([]*Foo{&foo}, di.GetAll[*Foo](ctx))
+	suite.Equal(bar, di.Get[Bar](ctx))
+}
+
+func (suite *MergeSuite) TestOverrideOnConflictingName() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	other := di.NewContextBuilder()
+	other.AddNamed("foo", &foo2)
+	ctxb.Merge(other, di.ConflictOverride)
+	ctx := ctxb.Build()
+	suite.Equal(&foo2, di.GetNamed[*Foo](ctx, "foo"))
+	suite.Equal([]*Foo{&foo2}, di.GetAll[*Foo](ctx))
+}
+
+func (suite *MergeSuite) TestAllowSameNameWithProfiles() {
+	ctxb := di.NewContextBuilder()
+	ctxb.ActivateProfiles("prod")
+	ctxb.AddNamed("foo", &foo, di.Profile("dev"))
+	other := di.NewContextBuilder()
+	other.AddNamed("foo", &foo2, di.Profile("prod"))
+	ctxb.Merge(other, di.ConflictFail)
+	ctx := ctxb.Build()
+	suite.Equal(&foo2, di.GetNamed[*Foo](ctx, "foo"))
+}
+
+func (suite *MergeSuite) TestErrorOnConflictingKey() {
+	ctxb := di.NewContextBuilder()
+	di.AddKeyed(ctxb, primaryFoo, &foo)
+	other := di.NewContextBuilder()
+	di.AddKeyed(other, primaryFoo, &foo2)
+	err := ctxb.MergeOrErr(other, di.ConflictFail)
+	suite.Equal("duplicated dependency key: *di_test.Foo (key: primary)", err.Error())
+}
+
+func (suite *MergeSuite) TestReportFirstConflictingKeyInOrder() {
+	for i := 0; i < 10; i++ {
+		ctxb := di.NewContextBuilder()
+		di.AddKeyed(ctxb, primaryFoo, &foo)
+		di.AddKeyed(ctxb, primaryBaz, Baz(&bar))
+		other := di.NewContextBuilder()
+		di.AddKeyed(other, primaryBaz, Baz(&foo2))
+		di.AddKeyed(other, primaryFoo, &foo2)
+		err := ctxb.MergeOrErr(other, di.ConflictFail)
+		suite.Equal("duplicated dependency key: *di_test.Foo (key: primary)", err.Error())
+	}
+}
+
+func (suite *MergeSuite) TestBindMergedConfigFromTargetSources() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddConfigSource(di.ConfigFromMap(map[string]any{"database.user": "admin"}))
+	other := di.NewContextBuilder()
+	di.BindConfig[DBConfig](other, "database")
+	ctxb.Merge(other, di.ConflictFail)
+	ctx := ctxb.Build()
+	suite.Equal("admin", di.Get[DBConfig](ctx).User)
+}
+
+func (suite *MergeSuite) TestMergeBuilderSettings() {
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	other := di.NewContextBuilder()
+	other.EnableLazyInitialization()
+	other.ExposeInterfaces(new(Baz))
+	other.Provide(func() *CtxAwareFoo {
+		inits++
+		return &CtxAwareFoo{}
+	})
+	ctxb.Merge(other, di.ConflictFail)
+	ctxb.Add(&bar)
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	suite.Equal(0, inits)
+	suite.Equal([]Baz{&foo, &bar}, di.GetAll[Baz](ctx))
+}
+
+func TestMergeSuite(t *testing.T) {
+	suite.Run(t, new(MergeSuite))
+}
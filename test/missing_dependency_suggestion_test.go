@@ -0,0 +1,71 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type MissingDependencySuggestionSuite struct {
+	suite.Suite
+}
+
+func (suite *MissingDependencySuggestionSuite) TestSuggestPointerVariant() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[Foo](ctx)
+	suite.Equal("missing dependency di_test.Foo, did you mean: *di_test.Foo", err.Error())
+	suite.Equal([]string{"*di_test.Foo"}, err.Suggestions())
+}
+
+func (suite *MissingDependencySuggestionSuite) TestSuggestValueVariant() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(bar)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Bar](ctx)
+	suite.Equal("missing dependency *di_test.Bar, did you mean: di_test.Bar", err.Error())
+}
+
+func (suite *MissingDependencySuggestionSuite) TestSuggestImplementations() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.AddNamed("bar", bar)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[Baz](ctx)
+	suite.Equal("missing dependency di_test.Baz, did you mean: *di_test.Foo, di_test.Bar (name: bar)", err.Error())
+}
+
+func (suite *MissingDependencySuggestionSuite) TestSuggestRegistrationUnderDifferentType() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamedAs("foo", new(Baz), &foo)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	suite.Equal("missing dependency *di_test.Foo, did you mean: *di_test.Foo (name: foo)", err.Error())
+}
+
+func (suite *MissingDependencySuggestionSuite) TestSuggestSimilarNames() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("primary-db", &foo)
+	ctxb.AddNamed("primary-dbs", &foo2)
+	ctxb.AddNamed("replica", bar)
+	ctx := ctxb.Build()
+	_, err := di.GetNamedOrErr[*Foo](ctx, "primary_db")
+	suite.Equal("missing dependency (name: primary_db), did you mean: "+
+		"*di_test.Foo (name: primary-db), *di_test.Foo (name: primary-dbs)", err.Error())
+}
+
+func (suite *MissingDependencySuggestionSuite) TestNoSuggestions() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(bar)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	suite.Equal("missing dependency *di_test.Foo", err.Error())
+	suite.Empty(err.Suggestions())
+}
+
+func TestMissingDependencySuggestionSuite(t *testing.T) {
+	suite.Run(t, new(MissingDependencySuggestionSuite))
+}
@@ -0,0 +1,87 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type MultipleResultsSuite struct {
+	suite.Suite
+}
+
+func (suite *MultipleResultsSuite) TestRegisterEachResult() {
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() (*Foo, *Bar, error) {
+		inits++
+		return &foo, &bar, nil
+	})
+	ctx := ctxb.Build()
+	suite.Equal(0, inits)
+	suite.Equal(&bar, di.Get[*Bar](ctx))
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	suite.Equal(1, inits)
+}
+
+func (suite *MultipleResultsSuite) TestErrorResult() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() (*Foo, *Bar, error) {
+		return nil, nil, errSimulated
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Bar](ctx)
+	suite.Equal(di.ErrTypeDependencyCreation, err.ErrType())
+	suite.ErrorIs(err, errSimulated)
+}
+
+func (suite *MultipleResultsSuite) TestRegisterResultStructFields() {
+	type Result struct {
+		di.Out
+		Foo     *Foo
+		Special *Foo `name:"special-foo"`
+		Bar     Bar
+		ignored *Bar
+	}
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() (Result, error) {
+		inits++
+		return Result{Foo: &foo, Special: &foo2, Bar: bar}, nil
+	})
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	suite.Equal([]*Foo{&foo, &foo2}, di.GetAll[*Foo](ctx))
+	suite.Equal(&foo2, di.GetNamed[*Foo](ctx, "special-foo"))
+	suite.Equal(bar, di.Get[Bar](ctx))
+	suite.False(di.Has[*Bar](ctx))
+	suite.False(di.Has[Result](ctx))
+	suite.Equal(1, inits)
+}
+
+func (suite *MultipleResultsSuite) TestErrorOnNamedRegistration() {
+	ctxb := di.NewContextBuilder()
+	err := ctxb.ProvideNamedOrErr("foo", func() (*Foo, *Bar) {
+		return &foo, &bar
+	})
+	suite.Equal("invalid dependency constructor: expected one result value with an optional error", err.Error())
+}
+
+func (suite *MultipleResultsSuite) TestErrorOnResultStructWithoutFields() {
+	type Result struct {
+		di.Out
+		foo *Foo
+	}
+	ctxb := di.NewContextBuilder()
+	err := ctxb.ProvideOrErr(func() Result {
+		return Result{foo: &foo}
+	})
+	suite.Equal("invalid dependency constructor: expected constructor result to provide at least one dependency", err.Error())
+	suite.Equal(di.ErrTypeInvalidConstructor, err.ErrType())
+}
+
+func TestMultipleResultsSuite(t *testing.T) {
+	suite.Run(t, new(MultipleResultsSuite))
+}
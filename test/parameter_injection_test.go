@@ -101,7 +101,8 @@ func (suite *ParameterInjectionSuite) TestInjectMissingParam() {
 	result, err := di.GetOrErr[*Boo](ctx)
 	suite.Nil(result)
 	suite.NotNil(err)
-	suite.Equal("could not create dependency *di_test.Boo, cause:\nmissing dependency *di_test.Bar", err.Error())
+	suite.Equal("could not create dependency *di_test.Boo: missing dependency *di_test.Bar\n"+
+		"failing constructor: func(*di_test.Foo, *di_test.Bar) *di_test.Boo", err.Error())
 }
 
 func (suite *ParameterInjectionSuite) TestInjectSliceOfInterfaces() {
@@ -161,6 +162,39 @@ func (suite *ParameterInjectionSuite) TestInjectSliceOfStructPtrs() {
 	suite.Equal("second", result.foo[1].id)
 }
 
+func (suite *ParameterInjectionSuite) TestInjectVariadicParam() {
+	type Boo struct {
+		foo *Foo
+		baz []Baz
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.AddAs(new(Baz), &foo)
+	ctxb.AddAs(new(Baz), &bar)
+	ctxb.Provide(func(foo *Foo, baz ...Baz) *Boo {
+		return &Boo{foo: foo, baz: baz}
+	})
+	ctx := ctxb.Build()
+	result := di.Get[*Boo](ctx)
+	suite.NotNil(result)
+	suite.Equal(&foo, result.foo)
+	suite.Equal([]Baz{&foo, &bar}, result.baz)
+}
+
+func (suite *ParameterInjectionSuite) TestInjectEmptyVariadicParam() {
+	type Boo struct {
+		baz []Baz
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func(baz ...Baz) *Boo {
+		return &Boo{baz: baz}
+	})
+	ctx := ctxb.Build()
+	result := di.Get[*Boo](ctx)
+	suite.NotNil(result)
+	suite.Equal(0, len(result.baz))
+}
+
 func TestParameterInjectionSuite(t *testing.T) {
 	suite.Run(t, new(ParameterInjectionSuite))
 }
@@ -0,0 +1,113 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type ParameterObjectSuite struct {
+	suite.Suite
+}
+
+func (suite *ParameterObjectSuite) TestInjectParameterStruct() {
+	type Params struct {
+		di.In
+		Foo      *Foo
+		Special  *Foo `name:"special-foo"`
+		Bazes    []Baz
+		Handlers []Baz `group:"handlers"`
+		Missing  *Bar  `optional:"true"`
+		Ctx      *di.Context
+	}
+	type Boo struct {
+		params Params
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.AddNamed("special-foo", &foo2)
+	ctxb.AddAs(new(Baz), &foo)
+	ctxb.AddAs(new(Baz), bar, di.Group("handlers"))
+	ctxb.Provide(func(p Params) *Boo {
+		return &Boo{params: p}
+	})
+	ctx := ctxb.Build()
+	params := di.Get[*Boo](ctx).params
+	suite.Equal(&foo, params.Foo)
+	suite.Equal(&foo2, params.Special)
+	suite.Equal([]Baz{&foo, bar}, params.Bazes)
+	suite.Equal([]Baz{bar}, params.Handlers)
+	suite.Nil(params.Missing)
+	suite.NotNil(params.Ctx)
+}
+
+func (suite *ParameterObjectSuite) TestErrorOnMissingRequiredField() {
+	type Params struct {
+		di.In
+		Bar *Bar
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func(p Params) *Foo {
+		return &foo
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	suite.Equal("could not create dependency *di_test.Foo: missing dependency *di_test.Bar\n"+
+		"failing constructor: func(di_test.Params) *di_test.Foo", err.Error())
+}
+
+func (suite *ParameterObjectSuite) TestErrorOnNonSliceGroupField() {
+	type Params struct {
+		di.In
+		Baz Baz `group:"handlers"`
+	}
+	ctxb := di.NewContextBuilder()
+	err := ctxb.ProvideOrErr(func(p Params) *Foo {
+		return &foo
+	})
+	suite.Equal("invalid dependency constructor: expected group field Baz to be a slice", err.Error())
+}
+
+func (suite *ParameterObjectSuite) TestValidateReachabilityThroughParameterStruct() {
+	type Params struct {
+		di.In
+		Special  *Foo  `name:"special-foo"`
+		Handlers []Baz `group:"handlers"`
+	}
+	type Boo struct{}
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("special-foo", &foo2)
+	ctxb.AddAs(new(Baz), bar, di.Group("handlers"))
+	ctxb.Provide(func(p Params) *Boo {
+		return &Boo{}
+	})
+	ctxb.DeclareRoots(new(*Boo))
+	_, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+}
+
+func (suite *ParameterObjectSuite) TestExposeDependencies() {
+	type Params struct {
+		di.In
+		Special  *Foo  `name:"special-foo"`
+		Handlers []Baz `group:"handlers"`
+		Bar      *Bar  `optional:"true"`
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func(p Params) *Foo {
+		return &foo
+	})
+	ctx := ctxb.Build()
+	deps := ctx.Registrations()[0].Dependencies
+	suite.Equal(3, len(deps))
+	suite.Equal("special-foo", deps[0].Name)
+	suite.Equal("handlers", deps[1].Group)
+	suite.True(deps[1].Multiple)
+	suite.True(deps[2].Optional)
+}
+
+func TestParameterObjectSuite(t *testing.T) {
+	suite.Run(t, new(ParameterObjectSuite))
+}
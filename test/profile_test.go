@@ -0,0 +1,109 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type ProfileSuite struct {
+	suite.Suite
+}
+
+func (suite *ProfileSuite) TestIncludeOnlyActiveProfiles() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddAs(new(Baz), &foo, di.Profile("prod"))
+	ctxb.AddAs(new(Baz), &bar, di.Profile("local"))
+	ctxb.AddAs(new(Baz), &foo2)
+	ctxb.ActivateProfiles("local")
+	ctx := ctxb.Build()
+	suite.Equal([]Baz{&bar, &foo2}, di.GetAll[Baz](ctx))
+	suite.Equal([]string{"local"}, ctx.ActiveProfiles())
+	suite.True(ctx.IsProfileActive("local"))
+	suite.False(ctx.IsProfileActive("prod"))
+}
+
+func (suite *ProfileSuite) TestNegatedProfile() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo, di.Profile("!prod"))
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	ctxb.ActivateProfiles("prod")
+	ctx = ctxb.Build()
+	suite.False(di.Has[*Foo](ctx))
+}
+
+func (suite *ProfileSuite) TestModuleProfiles() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddModule(func(ctxb *di.ContextBuilder) {
+		ctxb.Add(&foo)
+		ctxb.Add(&bar, di.Profile("test"))
+	}, di.Profile("prod"))
+	ctxb.ActivateProfiles("prod")
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	suite.False(di.Has[*Bar](ctx))
+}
+
+func (suite *ProfileSuite) TestProfilesFromEnv() {
+	suite.T().Setenv(di.ProfilesEnvVar, "prod, test")
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo, di.Profile("test"))
+	ctxb.ActivateProfiles("local")
+	ctx := ctxb.Build()
+	suite.Equal([]string{"local", "prod", "test"}, ctx.ActiveProfiles())
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+}
+
+func (suite *ProfileSuite) TestSameNameInDifferentProfiles() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo, di.Profile("prod"))
+	ctxb.AddNamed("foo", &foo2, di.Profile("local"))
+	ctxb.ActivateProfiles("local")
+	ctx := ctxb.Build()
+	suite.Equal(&foo2, di.GetNamed[*Foo](ctx, "foo"))
+}
+
+func (suite *ProfileSuite) TestErrorOnSameNameInActiveProfiles() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo, di.Profile("prod"))
+	ctxb.AddNamed("foo", &foo2, di.Profile("local"))
+	ctxb.ActivateProfiles("local", "prod")
+	_, err := ctxb.BuildOrErr()
+	suite.Equal("duplicated dependency name: foo", err.Error())
+}
+
+func (suite *ProfileSuite) TestErrorOnSameNameWithoutProfile() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo, di.Profile("prod"))
+	err := ctxb.AddNamedOrErr("foo", &foo2)
+	suite.Equal("duplicated dependency name: foo", err.Error())
+}
+
+func (suite *ProfileSuite) TestErrorOnConflictingOptionsForRegisteredConstructor() {
+	ctor := func() *Foo { return &foo }
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(ctor)
+	err := ctxb.ProvideNamedOrErr("foo", ctor, di.Profile("prod"))
+	suite.Equal(di.ErrTypeDuplicatedRegistration, err.ErrType())
+	suite.Contains(err.Error(), "duplicated registration with conflicting options")
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[*Foo](ctx))
+	suite.False(ctx.HasNamed("foo"))
+}
+
+func (suite *ProfileSuite) TestReuseRegisteredValueWithSameOptions() {
+	ctxb := di.NewContextBuilder()
+	ctxb.ActivateProfiles("prod")
+	ctxb.AddNamed("foo", &foo, di.Profile("prod"))
+	ctxb.AddAs(new(Baz), &foo, di.Profile("prod"))
+	ctx := ctxb.Build()
+	suite.Equal(&foo, di.Get[Baz](ctx))
+	suite.Equal(1, len(ctx.Registrations()))
+}
+
+func TestProfileSuite(t *testing.T) {
+	suite.Run(t, new(ProfileSuite))
+}
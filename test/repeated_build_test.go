@@ -0,0 +1,69 @@
+package di_test
+
+import (
+	stdcontext "context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type RepeatedBuildSuite struct {
+	suite.Suite
+}
+
+func (suite *RepeatedBuildSuite) TestCreateInstancesPerContext() {
+	inits := 0
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *Foo {
+		inits++
+		return &Foo{id: "foo"}
+	})
+	first := ctxb.Build()
+	second := ctxb.Build()
+	suite.NotSame(di.Get[*Foo](first), di.Get[*Foo](second))
+	suite.Same(di.Get[*Foo](first), di.Get[*Foo](first))
+	suite.Equal(2, inits)
+}
+
+func (suite *RepeatedBuildSuite) TestTrackCreationPerContext() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *Foo {
+		return &foo
+	})
+	first := ctxb.Build()
+	second := ctxb.Build()
+	di.Get[*Foo](first)
+	suite.True(first.Registrations()[0].Created)
+	suite.False(second.Registrations()[0].Created)
+	suite.Equal(1, len(second.UnusedRegistrations()))
+}
+
+func (suite *RepeatedBuildSuite) TestIgnoreRegistrationsAfterBuild() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	ctx := ctxb.Build()
+	ctxb.AddNamed("foo2", &foo2)
+	ctxb.AddAs(new(Baz), &foo2)
+	suite.False(ctx.HasNamed("foo2"))
+	suite.False(di.Has[Baz](ctx))
+	suite.True(ctxb.Build().HasNamed("foo2"))
+}
+
+func (suite *RepeatedBuildSuite) TestShutdownPerContext() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *CtxAwareFoo {
+		return &CtxAwareFoo{}
+	})
+	first := ctxb.Build()
+	second := ctxb.Build()
+	firstFoo := di.Get[*CtxAwareFoo](first)
+	first.Shutdown(stdcontext.Background())
+	suite.Equal(1, firstFoo.shutdown)
+	suite.Equal(0, di.Get[*CtxAwareFoo](second).shutdown)
+}
+
+func TestRepeatedBuildSuite(t *testing.T) {
+	suite.Run(t, new(RepeatedBuildSuite))
+}
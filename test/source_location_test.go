@@ -0,0 +1,108 @@
+package di_test
+
+import (
+	stdcontext "context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type SourceLocationSuite struct {
+	suite.Suite
+}
+
+func (suite *SourceLocationSuite) TestExposeRegistrationLocation() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	location := callerLocation(-1)
+	ctx := ctxb.Build()
+	suite.Equal(location, ctx.Registrations()[0].Location)
+}
+
+func (suite *SourceLocationSuite) TestExposeGenericRegistrationLocation() {
+	ctxb := di.NewContextBuilder()
+	di.Provide[*Foo](ctxb, func() *Foo { return &foo })
+	location := callerLocation(-1)
+	ctx := ctxb.Build()
+	suite.Equal(location, ctx.Registrations()[0].Location)
+}
+
+func (suite *SourceLocationSuite) TestExposeModuleRegistrationLocation() {
+	ctxb := di.NewContextBuilder()
+	var location string
+	ctxb.AddModule(func(ctxb *di.ContextBuilder) {
+		ctxb.Add(&foo)
+		location = callerLocation(-1)
+	})
+	ctx := ctxb.Build()
+	suite.Equal(location, ctx.Registrations()[0].Location)
+}
+
+func (suite *SourceLocationSuite) TestIncludeLocationInRegistrationError() {
+	ctxb := di.NewContextBuilder()
+	ctxb.AddNamed("foo", &foo)
+	err := ctxb.AddNamedOrErr("foo", &foo2)
+	location := callerLocation(-1)
+	suite.Equal("duplicated dependency name: foo", err.Error())
+	suite.Equal(location, err.Location())
+	suite.Equal("duplicated dependency name: foo\nregistered at: "+location, fmt.Sprintf("%+v", err))
+}
+
+func (suite *SourceLocationSuite) TestIncludeLocationInConstructorResultError() {
+	ctxb := di.NewContextBuilder()
+	err := di.ProvideOrErr[*Bar](ctxb, func() *Foo { return &foo })
+	location := callerLocation(-1)
+	suite.Equal(di.ErrTypeInvalidConstructor, err.ErrType())
+	suite.Equal(location, err.Location())
+	err = di.ProvideNamedOrErr[*Bar](ctxb, "foo", func() *Foo { return &foo })
+	location = callerLocation(-1)
+	suite.Equal(location, err.Location())
+}
+
+func (suite *SourceLocationSuite) TestIncludeLocationInCreationError() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() (*Foo, error) { return nil, errSimulated })
+	location := callerLocation(-1)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	suite.Equal(location, err.Location())
+	suite.Contains(fmt.Sprintf("%+v", err), "\nregistered at: "+location)
+	suite.NotContains(err.Error(), "registered at")
+}
+
+func (suite *SourceLocationSuite) TestIncludeLocationInLifecycleErrors() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&CtxAwareFoo{errOnInitialize: true, errOnShutdown: true})
+	location := callerLocation(-1)
+	ctx := ctxb.Build()
+	err := ctx.InitializeOrErr()
+	suite.Equal(di.ErrTypeDependencyInitialization, err.ErrType())
+	suite.Equal(location, err.Location())
+	err = ctx.ShutdownOrErr(stdcontext.Background())
+	suite.Equal(di.ErrTypeDependencyShutdown, err.ErrType())
+	suite.Equal(location, err.Location())
+}
+
+func (suite *SourceLocationSuite) TestDisableSourceLocations() {
+	ctxb := di.NewContextBuilder()
+	ctxb.DisableSourceLocations()
+	ctxb.AddNamed("foo", &foo)
+	err := ctxb.AddNamedOrErr("foo", &foo2)
+	ctx := ctxb.Build()
+	suite.Equal("duplicated dependency name: foo", err.Error())
+	suite.Empty(err.Location())
+	suite.Empty(ctx.Registrations()[0].Location)
+}
+
+func callerLocation(lineOffset int) string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", file, line+lineOffset)
+}
+
+func TestSourceLocationSuite(t *testing.T) {
+	suite.Run(t, new(SourceLocationSuite))
+}
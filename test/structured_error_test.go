@@ -0,0 +1,105 @@
+package di_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type StructuredErrorSuite struct {
+	suite.Suite
+}
+
+func (suite *StructuredErrorSuite) TestMissingDependencyDetails() {
+	type Boo struct{}
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func(bar *Bar) *Boo {
+		return &Boo{}
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Boo](ctx)
+	suite.ErrorIs(err, di.ErrDependencyCreation)
+	suite.ErrorIs(err, di.ErrMissingDependency)
+	suite.NotErrorIs(err, di.ErrCyclicDependency)
+	suite.Equal(reflect.TypeOf(&Boo{}), err.Type())
+	suite.Equal([]string{"*di_test.Boo"}, err.Path())
+	var missing *di.Error
+	suite.True(errors.As(err.Unwrap(), &missing))
+	suite.Equal(reflect.TypeOf(&bar), missing.Type())
+	suite.Equal([]string{"*di_test.Boo", "*di_test.Bar"}, missing.Path())
+}
+
+func (suite *StructuredErrorSuite) TestWrappedCreationErrorDetails() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() (*Bar, error) {
+		return nil, errSimulated
+	})
+	ctxb.Provide(func(ctx *di.Context) (*Foo, error) {
+		if _, err := di.GetOrErr[*Bar](ctx); err != nil {
+			return nil, fmt.Errorf("wiring foo: %w", err)
+		}
+		return &foo, nil
+	})
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*Foo](ctx)
+	suite.ErrorIs(err, errSimulated)
+	suite.Contains(err.Error(), "wiring foo")
+	suite.Equal(reflect.TypeOf(&foo), err.Type())
+	suite.Equal([]string{"*di_test.Foo"}, err.Path())
+}
+
+func (suite *StructuredErrorSuite) TestMissingNamedDependencyDetails() {
+	ctxb := di.NewContextBuilder()
+	ctx := ctxb.Build()
+	_, err := di.GetNamedOrErr[*Foo](ctx, "foo")
+	suite.ErrorIs(err, di.ErrMissingDependency)
+	suite.Equal("foo", err.Name())
+	suite.Nil(err.Type())
+}
+
+func (suite *StructuredErrorSuite) TestCycleDetails() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(provideCyclicFoo)
+	ctxb.Provide(provideCyclicBar)
+	ctxb.Provide(provideCyclicBaz)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[*cyclicFoo](ctx)
+	suite.ErrorIs(err, di.ErrCyclicDependency)
+	cycle := err.RootCause().(*di.Error).Cycle()
+	suite.Equal([]string{"*di_test.cyclicFoo", "*di_test.cyclicBar", "*di_test.cyclicBaz"}, cycle)
+}
+
+func (suite *StructuredErrorSuite) TestCandidatesDetails() {
+	ctxb := di.NewContextBuilder()
+	ctxb.EnableImplicitInterfaces()
+	ctxb.Add(&foo)
+	ctxb.Add(&bar)
+	ctx := ctxb.Build()
+	_, err := di.GetOrErr[Baz](ctx)
+	suite.ErrorIs(err, di.ErrAmbiguousDependency)
+	suite.Equal([]string{"*di_test.Foo", "*di_test.Bar"}, err.Candidates())
+}
+
+func (suite *StructuredErrorSuite) TestErrTypeString() {
+	suite.Equal("missing dependency", di.ErrTypeMissingDependency.String())
+	suite.Equal("cyclic dependency", di.ErrTypeCyclicDependency.String())
+	suite.Equal("missing dependency", di.ErrMissingDependency.Error())
+}
+
+func (suite *StructuredErrorSuite) TestFormatVerbs() {
+	ctxb := di.NewContextBuilder()
+	ctx := ctxb.Build()
+	_, err := di.GetNamedOrErr[*Foo](ctx, "foo")
+	suite.Equal("missing dependency (name: foo)", fmt.Sprintf("%s", err))
+	suite.Equal("\"missing dependency (name: foo)\"", fmt.Sprintf("%q", err))
+	suite.Equal("missing dependency (name: foo)", fmt.Sprintf("%d", err))
+}
+
+func TestStructuredErrorSuite(t *testing.T) {
+	suite.Run(t, new(StructuredErrorSuite))
+}
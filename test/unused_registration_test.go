@@ -0,0 +1,79 @@
+package di_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	di "github.com/coditory/go-di"
+)
+
+type UnusedRegistrationSuite struct {
+	suite.Suite
+}
+
+func (suite *UnusedRegistrationSuite) TestTrackTransitivelyResolvedDependencies() {
+	type Boo struct {
+		foo *Foo
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.Add(&bar)
+	ctxb.Provide(func(foo *Foo) *Boo {
+		return &Boo{foo: foo}
+	})
+	ctx := ctxb.Build()
+	suite.Equal(3, len(ctx.UnusedRegistrations()))
+	di.Get[*Boo](ctx)
+	unused := ctx.UnusedRegistrations()
+	suite.Equal(1, len(unused))
+	suite.Equal(reflect.TypeOf(&bar), unused[0].Type)
+	suite.False(unused[0].Used)
+}
+
+func (suite *UnusedRegistrationSuite) TestIgnoreCreationForInitialization() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Provide(func() *CtxAwareFoo {
+		return &CtxAwareFoo{}
+	})
+	ctx := ctxb.Build()
+	ctx.Initialize()
+	unused := ctx.UnusedRegistrations()
+	suite.Equal(1, len(unused))
+	suite.Equal(reflect.TypeOf(&CtxAwareFoo{}), unused[0].Type)
+	suite.Equal(1, di.Get[*CtxAwareFoo](ctx).initialized)
+	suite.Empty(ctx.UnusedRegistrations())
+}
+
+func (suite *UnusedRegistrationSuite) TestValidateReachabilityFromRoots() {
+	type Boo struct {
+		baz []Baz
+	}
+	ctxb := di.NewContextBuilder()
+	ctxb.AddAs(new(Baz), &foo)
+	ctxb.AddAs(new(Baz), &bar)
+	ctxb.Provide(func(baz []Baz) *Boo {
+		return &Boo{baz: baz}
+	})
+	ctxb.DeclareRoots(new(*Boo))
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(err)
+	suite.NotNil(ctx)
+}
+
+func (suite *UnusedRegistrationSuite) TestErrorOnUnreachableRegistration() {
+	ctxb := di.NewContextBuilder()
+	ctxb.Add(&foo)
+	ctxb.AddNamed("bar", &bar)
+	ctxb.Add(42)
+	ctxb.DeclareRoots(new(int))
+	ctx, err := ctxb.BuildOrErr()
+	suite.Nil(ctx)
+	suite.Equal("unreachable dependencies: *di_test.Foo, *di_test.Bar (name: bar)", err.Error())
+	suite.Equal(di.ErrTypeUnreachableDependency, err.ErrType())
+}
+
+func TestUnusedRegistrationSuite(t *testing.T) {
+	suite.Run(t, new(UnusedRegistrationSuite))
+}
@@ -16,3 +16,56 @@ func genericTypeOf[T any]() reflect.Type {
 	}
 	return ttype
 }
+
+func elemTypes(atypes []any) []reflect.Type {
+	result := make([]reflect.Type, len(atypes))
+	for i, atype := range atypes {
+		result[i] = reflect.TypeOf(atype).Elem()
+	}
+	return result
+}
+
+func embedsType(rtype reflect.Type, embedded reflect.Type) bool {
+	if rtype.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < rtype.NumField(); i++ {
+		field := rtype.Field(i)
+		if field.Anonymous && field.Type == embedded {
+			return true
+		}
+	}
+	return false
+}
+
+func derefType(rtype *reflect.Type) reflect.Type {
+	if rtype == nil {
+		return nil
+	}
+	return *rtype
+}
+
+func derefString(text *string) string {
+	if text == nil {
+		return ""
+	}
+	return *text
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	copy(result, values)
+	return result
+}
+
+func containsType(rtypes []reflect.Type, rtype reflect.Type) bool {
+	for _, t := range rtypes {
+		if t == rtype {
+			return true
+		}
+	}
+	return false
+}